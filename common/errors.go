@@ -0,0 +1,132 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("monime: unauthorized")
+	ErrBadRequest   = errors.New("monime: bad request")
+	ErrNotFound     = errors.New("monime: not found")
+	ErrConflict     = errors.New("monime: conflict")
+	ErrRateLimited  = errors.New("monime: rate limited")
+	ErrServerError  = errors.New("monime: internal server error")
+)
+
+// FieldError is a validation failure the API reported for a single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError is returned by rest.Client for any response with status >= 400.
+// errors.Is matches it against the sentinel for its status class, e.g.
+// ErrNotFound for a 404.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    []FieldError
+	RequestID  string
+	Method     string
+	Path       string
+	Body       []byte
+}
+
+type errorEnvelope struct {
+	Messages []any `json:"messages"`
+	Error    struct {
+		Code    any               `json:"code"`
+		Reason  string            `json:"reason"`
+		Message string            `json:"message"`
+		Details []json.RawMessage `json:"details"`
+	} `json:"error"`
+}
+
+// NewAPIError builds an APIError from a failed response and its body. A body
+// that is not a Monime error envelope is kept verbatim as the message.
+func NewAPIError(method, path string, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     method,
+		Path:       path,
+		Body:       body,
+		RequestID:  res.Header.Get("Monime-Request-Id"),
+	}
+
+	if e.RequestID == "" {
+		e.RequestID = res.Header.Get("X-Request-Id")
+	}
+
+	var env errorEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+		return e
+	}
+
+	e.Code = env.Error.Reason
+	if e.Code == "" && env.Error.Code != nil {
+		e.Code = fmt.Sprint(env.Error.Code)
+	}
+
+	e.Message = env.Error.Message
+	if e.Message == "" && len(env.Messages) > 0 {
+		e.Message = fmt.Sprint(env.Messages[0])
+	}
+
+	for _, raw := range env.Error.Details {
+		var fe FieldError
+		if err := json.Unmarshal(raw, &fe); err == nil && (fe.Field != "" || fe.Message != "") {
+			e.Details = append(e.Details, fe)
+		}
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "monime: %s %s failed with status=%d", e.Method, e.Path, e.StatusCode)
+
+	if e.Code != "" {
+		fmt.Fprintf(&b, " code=%s", e.Code)
+	}
+
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	for _, d := range e.Details {
+		fmt.Fprintf(&b, "; %s: %s", d.Field, d.Message)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request_id=%s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// Is reports whether target is the sentinel for the error's status class.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
@@ -0,0 +1,160 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    string
+		code    string
+		message string
+		details []FieldError
+		request string
+	}{
+		{
+			name:    "reason",
+			body:    `{"error":{"code":400,"reason":"invalid_request","message":"bad amount"}}`,
+			code:    "invalid_request",
+			message: "bad amount",
+		},
+		{
+			name:    "numeric code",
+			body:    `{"error":{"code":404,"message":"no such payout"}}`,
+			code:    "404",
+			message: "no such payout",
+		},
+		{
+			name: "string code",
+			body: `{"error":{"code":"not_found"}}`,
+			code: "not_found",
+		},
+		{
+			name:    "messages fallback",
+			body:    `{"messages":["space is suspended","ignored"],"error":{"reason":"forbidden"}}`,
+			code:    "forbidden",
+			message: "space is suspended",
+		},
+		{
+			name:    "message wins over messages",
+			body:    `{"messages":["ignored"],"error":{"message":"used"}}`,
+			message: "used",
+		},
+		{
+			name: "details",
+			body: `{"error":{"reason":"validation_failed","details":[{"field":"amount.value","message":"must be positive"},"free text",{},{"message":"name is taken"}]}}`,
+			code: "validation_failed",
+			details: []FieldError{
+				{Field: "amount.value", Message: "must be positive"},
+				{Message: "name is taken"},
+			},
+		},
+		{
+			name:    "non-JSON body",
+			body:    "  upstream connect error\n",
+			message: "upstream connect error",
+		},
+		{
+			name: "empty body",
+		},
+		{
+			name:    "Monime-Request-Id",
+			header:  http.Header{"Monime-Request-Id": {"req-1"}, "X-Request-Id": {"req-2"}},
+			body:    `{}`,
+			request: "req-1",
+		},
+		{
+			name:    "X-Request-Id fallback",
+			header:  http.Header{"X-Request-Id": {"req-2"}},
+			body:    `{}`,
+			request: "req-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{StatusCode: http.StatusBadRequest, Header: tt.header}
+			if res.Header == nil {
+				res.Header = http.Header{}
+			}
+
+			e := NewAPIError(http.MethodPost, "/payouts", res, []byte(tt.body))
+
+			if e.StatusCode != http.StatusBadRequest || e.Method != http.MethodPost || e.Path != "/payouts" {
+				t.Fatalf("NewAPIError() = %d %s %s, want 400 POST /payouts", e.StatusCode, e.Method, e.Path)
+			}
+			if string(e.Body) != tt.body {
+				t.Errorf("Body = %q, want %q", e.Body, tt.body)
+			}
+			if e.Code != tt.code {
+				t.Errorf("Code = %q, want %q", e.Code, tt.code)
+			}
+			if e.Message != tt.message {
+				t.Errorf("Message = %q, want %q", e.Message, tt.message)
+			}
+			if !slices.Equal(e.Details, tt.details) {
+				t.Errorf("Details = %v, want %v", e.Details, tt.details)
+			}
+			if e.RequestID != tt.request {
+				t.Errorf("RequestID = %q, want %q", e.RequestID, tt.request)
+			}
+		})
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrBadRequest, ErrUnauthorized, ErrNotFound, ErrConflict, ErrRateLimited, ErrServerError}
+
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnprocessableEntity, ErrBadRequest},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusBadGateway, ErrServerError},
+		{http.StatusServiceUnavailable, ErrServerError},
+		{http.StatusGatewayTimeout, ErrServerError},
+		{http.StatusPaymentRequired, nil},
+		{http.StatusGone, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var err error = &APIError{StatusCode: tt.status}
+
+			for _, target := range sentinels {
+				if got := errors.Is(err, target); got != (target == tt.want) {
+					t.Errorf("errors.Is(%d, %v) = %v", tt.status, target, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorString(t *testing.T) {
+	e := &APIError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "validation_failed",
+		Message:    "invalid payout",
+		Details:    []FieldError{{Field: "amount.value", Message: "must be positive"}},
+		RequestID:  "req-1",
+		Method:     http.MethodPost,
+		Path:       "/payouts",
+	}
+
+	want := "monime: POST /payouts failed with status=400 code=validation_failed: invalid payout; amount.value: must be positive (request_id=req-1)"
+	if got := e.Error(); got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
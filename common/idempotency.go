@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ose-micro/core/utils"
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context whose mutating request will be sent
+// with key as its Idempotency-Key. Use a fresh context per request, since
+// every request made with ctx shares the key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key set by WithIdempotencyKey.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key, ok && key != ""
+}
+
+// IdempotencyKey picks the Idempotency-Key for a mutating request. An
+// explicit key on the command wins, then one set on ctx. Otherwise the key
+// is derived from the caller's reference and the request path, so a job
+// that is replayed after a crash or timeout sends the same key and Monime
+// returns the original result instead of creating the resource twice.
+// Commands whose reference is not part of the API payload still take one
+// for this purpose alone; it is never sent.
+//
+// Requests without a reference get a random key. Updates always pass an
+// empty reference, since deriving their key from the payload would turn a
+// later update back to an earlier value into a replay of the earlier
+// response.
+func IdempotencyKey(ctx context.Context, explicit, reference, path string) string {
+	if explicit != "" {
+		return explicit
+	}
+
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return key
+	}
+
+	if reference == "" {
+		return utils.GenerateUUID()
+	}
+
+	h := sha256.New()
+	h.Write([]byte(reference))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIdempotencyKeyPrecedence(t *testing.T) {
+	withKey := WithIdempotencyKey(context.Background(), "ctx-key")
+	derived := IdempotencyKey(context.Background(), "", "job-1", "/payouts")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		explicit  string
+		reference string
+		want      string
+	}{
+		{"command", withKey, "cmd-key", "job-1", "cmd-key"},
+		{"context", withKey, "", "job-1", "ctx-key"},
+		{"empty context key", WithIdempotencyKey(context.Background(), ""), "", "job-1", derived},
+		{"derived", context.Background(), "", "job-1", derived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdempotencyKey(tt.ctx, tt.explicit, tt.reference, "/payouts"); got != tt.want {
+				t.Fatalf("IdempotencyKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyRandom(t *testing.T) {
+	ctx := context.Background()
+
+	first := IdempotencyKey(ctx, "", "", "/payouts")
+	second := IdempotencyKey(ctx, "", "", "/payouts")
+	if first == "" || first == second {
+		t.Fatalf("IdempotencyKey() without a reference = %q then %q, want distinct keys", first, second)
+	}
+}
+
+func TestIdempotencyKeyDerived(t *testing.T) {
+	ctx := context.Background()
+	key := IdempotencyKey(ctx, "", "job-1", "/payouts")
+
+	if again := IdempotencyKey(ctx, "", "job-1", "/payouts"); again != key {
+		t.Fatalf("IdempotencyKey() = %q then %q, want a stable key", key, again)
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		path      string
+	}{
+		{"other reference", "job-2", "/payouts"},
+		{"other path", "job-1", "/internal-transfers"},
+		{"boundary moved", "job-1/", "payouts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdempotencyKey(ctx, "", tt.reference, tt.path); got == key {
+				t.Fatalf("IdempotencyKey(%q, %q) = %q, same as for job-1 on /payouts", tt.reference, tt.path, got)
+			}
+		})
+	}
+}
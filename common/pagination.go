@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions selects a page of a List call. Pass PaginationInfo.Next of the
+// previous page as After to fetch the following one.
+type ListOptions struct {
+	Limit int
+	After string
+}
+
+// Query encodes the options as URL query values.
+func (o *ListOptions) Query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.After != "" {
+		q.Set("after", o.After)
+	}
+
+	return q
+}
+
+// Paginate walks every page of a List call starting at opts, following
+// PaginationInfo.Next until it is empty. Iteration stops at the first error,
+// which is yielded with a zero T.
+func Paginate[T any](ctx context.Context, opts *ListOptions, list func(context.Context, *ListOptions) (*Response[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var page ListOptions
+		if opts != nil {
+			page = *opts
+		}
+
+		for {
+			res, err := list(ctx, &page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range res.Result {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next := res.Pagination.Next
+			if next == "" || next == page.After || len(res.Result) == 0 {
+				return
+			}
+			page.After = next
+		}
+	}
+}
+
+// Collect gathers up to max items from seq; max <= 0 means no cap.
+func Collect[T any](seq iter.Seq2[T, error], max int) ([]T, error) {
+	var out []T
+
+	for item, err := range seq {
+		if err != nil {
+			return out, err
+		}
+
+		out = append(out, item)
+		if max > 0 && len(out) >= max {
+			break
+		}
+	}
+
+	return out, nil
+}
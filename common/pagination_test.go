@@ -0,0 +1,164 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// pager serves items in pages of opts.Limit, using the index of the last
+// item of a page as its cursor, and records the options of every call.
+type pager struct {
+	items []int
+	fail  int
+	calls []ListOptions
+}
+
+func (p *pager) list(_ context.Context, opts *ListOptions) (*Response[int], error) {
+	p.calls = append(p.calls, *opts)
+	if p.fail > 0 && len(p.calls) == p.fail {
+		return nil, ErrServerError
+	}
+
+	start := 0
+	if opts.After != "" {
+		i, err := strconv.Atoi(opts.After)
+		if err != nil {
+			return nil, err
+		}
+		start = i + 1
+	}
+
+	end := min(start+opts.Limit, len(p.items))
+	res := &Response[int]{Result: p.items[start:end]}
+	if end < len(p.items) {
+		res.Pagination.Next = strconv.Itoa(end - 1)
+	}
+
+	return res, nil
+}
+
+func TestPaginate(t *testing.T) {
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+
+	got, err := Collect(Paginate(context.Background(), &ListOptions{Limit: 2}, p.list), 0)
+	if err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	if !slices.Equal(got, p.items) {
+		t.Fatalf("Paginate() = %v, want %v", got, p.items)
+	}
+
+	want := []ListOptions{{Limit: 2}, {Limit: 2, After: "1"}, {Limit: 2, After: "3"}}
+	if !slices.Equal(p.calls, want) {
+		t.Fatalf("list called with %v, want %v", p.calls, want)
+	}
+}
+
+func TestPaginateReiterates(t *testing.T) {
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+	opts := &ListOptions{Limit: 2}
+
+	seq := Paginate(context.Background(), opts, p.list)
+
+	for i := range 2 {
+		got, err := Collect(seq, 0)
+		if err != nil {
+			t.Fatalf("range %d: Collect() = %v", i+1, err)
+		}
+		if !slices.Equal(got, p.items) {
+			t.Fatalf("range %d: Paginate() = %v, want %v", i+1, got, p.items)
+		}
+	}
+
+	if *opts != (ListOptions{Limit: 2}) {
+		t.Fatalf("Paginate() changed the caller's options to %+v", *opts)
+	}
+}
+
+func TestPaginateStartsAfterCursor(t *testing.T) {
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+
+	got, err := Collect(Paginate(context.Background(), &ListOptions{Limit: 2, After: "2"}, p.list), 0)
+	if err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	if want := []int{4, 5}; !slices.Equal(got, want) {
+		t.Fatalf("Paginate() = %v, want %v", got, want)
+	}
+}
+
+func TestPaginateStopsAtError(t *testing.T) {
+	p := &pager{items: []int{1, 2, 3, 4, 5}, fail: 2}
+
+	got, err := Collect(Paginate(context.Background(), &ListOptions{Limit: 2}, p.list), 0)
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Collect() error = %v, want %v", err, ErrServerError)
+	}
+
+	if want := []int{1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("Collect() = %v, want %v", got, want)
+	}
+	if len(p.calls) != 2 {
+		t.Fatalf("list called %d times, want 2", len(p.calls))
+	}
+}
+
+func TestPaginateStopsOnRepeatedCursor(t *testing.T) {
+	var calls int
+	list := func(context.Context, *ListOptions) (*Response[int], error) {
+		calls++
+		return &Response[int]{Result: []int{calls}, Pagination: PaginationInfo{Next: "same"}}, nil
+	}
+
+	got, err := Collect(Paginate(context.Background(), nil, list), 0)
+	if err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	if want := []int{1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("Paginate() = %v, want %v", got, want)
+	}
+}
+
+func TestCollectMax(t *testing.T) {
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+
+	got, err := Collect(Paginate(context.Background(), &ListOptions{Limit: 2}, p.list), 3)
+	if err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("Collect() = %v, want %v", got, want)
+	}
+	if len(p.calls) != 2 {
+		t.Fatalf("list called %d times, want 2", len(p.calls))
+	}
+}
+
+func TestListOptionsQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *ListOptions
+		want string
+	}{
+		{"nil", nil, ""},
+		{"zero", &ListOptions{}, ""},
+		{"limit", &ListOptions{Limit: 10}, "limit=10"},
+		{"negative limit", &ListOptions{Limit: -1}, ""},
+		{"cursor", &ListOptions{Limit: 10, After: "pyt-1"}, "after=pyt-1&limit=10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Query().Encode(); got != tt.want {
+				t.Fatalf("Query() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
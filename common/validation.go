@@ -0,0 +1,97 @@
+package common
+
+import (
+	"strings"
+)
+
+// ValidationError is returned by a service method whose command failed
+// client-side validation; the request is not sent. Fields name each failing
+// field by its JSON path, e.g. lineItems[0].price.value, so callers can
+// point end users at the offending input. errors.Is matches it against
+// ErrBadRequest, like the 400 the API would have answered with.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add records that field failed with message, e.g. Add("name", "is required").
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// Nest adds the failures of a nested value's Validate under prefix, so
+// "price.value" reported by a line item becomes "lineItems[0].price.value".
+// err may be nil; an error that is not a *ValidationError is recorded
+// against prefix itself.
+func (e *ValidationError) Nest(prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	nested, ok := err.(*ValidationError)
+	if !ok {
+		e.Add(prefix, err.Error())
+		return
+	}
+
+	for _, f := range nested.Fields {
+		e.Add(joinPath(prefix, f.Field), f.Message)
+	}
+}
+
+// Err returns the error, or nil when no field has failed.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: append([]FieldError(nil), e.Fields...)}
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, strings.TrimSpace(f.Field+" "+f.Message))
+	}
+
+	return "monime: invalid request: " + strings.Join(msgs, ", ")
+}
+
+// Is reports whether target is ErrBadRequest.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrBadRequest
+}
+
+// Validate runs cmd.Validate, treating a nil command as invalid rather
+// than letting it panic.
+func Validate[T any, P interface {
+	*T
+	Validate() error
+}](cmd P) error {
+	if cmd == nil {
+		return &ValidationError{Fields: []FieldError{{Message: "command is required"}}}
+	}
+
+	return cmd.Validate()
+}
+
+// ValidateID checks the id passed to Get and Delete, which would otherwise
+// address the collection instead of a single resource.
+func ValidateID(id string) error {
+	var errs ValidationError
+	if strings.TrimSpace(id) == "" {
+		errs.Add("id", "is required")
+	}
+
+	return errs.Err()
+}
+
+func joinPath(prefix, field string) string {
+	switch {
+	case field == "":
+		return prefix
+	case prefix == "" || strings.HasPrefix(field, "["):
+		return prefix + field
+	default:
+		return prefix + "." + field
+	}
+}
@@ -1,9 +1,28 @@
 package monime
 
 type Config struct {
-	BaseURL    string `mapstructure:"base_url"`
-	Access     string `mapstructure:"access"`
-	Space      string `mapstructure:"space"`
-	Version    string `mapstructure:"version"`
-	TimeoutSec int    `mapstructure:"timeout_sec"`
+	BaseURL    string          `mapstructure:"base_url"`
+	Access     string          `mapstructure:"access"`
+	Space      string          `mapstructure:"space"`
+	Version    string          `mapstructure:"version"`
+	TimeoutSec int             `mapstructure:"timeout_sec"`
+	Retry      RetryConfig     `mapstructure:"retry"`
+	RateLimit  RateLimitConfig `mapstructure:"rate_limit"`
+}
+
+// RetryConfig tunes retries of transient failures. Zero values fall back to
+// rest.DefaultRetryPolicy; set MaxAttempts to 1 to disable retries.
+type RetryConfig struct {
+	MaxAttempts     int     `mapstructure:"max_attempts"`
+	BaseDelayMs     int     `mapstructure:"base_delay_ms"`
+	MaxDelayMs      int     `mapstructure:"max_delay_ms"`
+	Jitter          float64 `mapstructure:"jitter"`
+	RetryableStatus []int   `mapstructure:"retryable_status"`
+}
+
+// RateLimitConfig enables a client-side limit on requests to Config.Space.
+// It is disabled while RequestsPerSecond is zero.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
 }
@@ -1,6 +1,8 @@
 package monime
 
 import (
+	"time"
+
 	"github.com/ose-micro/core/logger"
 	"github.com/ose-micro/core/tracing"
 	"github.com/ose-micro/monime/rest"
@@ -18,8 +20,19 @@ func (m Monime) Services() *services.Service {
 	return m.services
 }
 
-func New(conf *Config, log logger.Logger, tracer tracing.Tracer) *Monime {
-	client := rest.New(conf.BaseURL, conf.Access, conf.Space, conf.Version, conf.TimeoutSec, log, tracer)
+// Use registers middlewares on the underlying rest.Client; see rest.Middleware.
+func (m Monime) Use(middlewares ...rest.Middleware) {
+	m.httpClient.Use(middlewares...)
+}
+
+func New(conf *Config, log logger.Logger, tracer tracing.Tracer, opts ...Option) *Monime {
+	defaults := []Option{rest.WithRetryPolicy(conf.Retry.policy())}
+	if conf.RateLimit.RequestsPerSecond > 0 {
+		limiter := rest.NewRateLimiter(conf.RateLimit.RequestsPerSecond, conf.RateLimit.Burst)
+		defaults = append(defaults, rest.WithRateLimiter(limiter))
+	}
+	opts = append(defaults, opts...)
+	client := rest.New(conf.BaseURL, conf.Access, conf.Space, conf.Version, conf.TimeoutSec, log, tracer, opts...)
 	svc := services.NewService(client, log, tracer)
 
 	return &Monime{
@@ -29,3 +42,29 @@ func New(conf *Config, log logger.Logger, tracer tracing.Tracer) *Monime {
 		services:   svc,
 	}
 }
+
+func (r RetryConfig) policy() rest.RetryPolicy {
+	policy := rest.DefaultRetryPolicy()
+
+	if r.MaxAttempts > 0 {
+		policy.MaxAttempts = r.MaxAttempts
+	}
+
+	if r.BaseDelayMs > 0 {
+		policy.BaseDelay = time.Duration(r.BaseDelayMs) * time.Millisecond
+	}
+
+	if r.MaxDelayMs > 0 {
+		policy.MaxDelay = time.Duration(r.MaxDelayMs) * time.Millisecond
+	}
+
+	if r.Jitter > 0 {
+		policy.Jitter = r.Jitter
+	}
+
+	if len(r.RetryableStatus) > 0 {
+		policy.RetryableStatus = r.RetryableStatus
+	}
+
+	return policy
+}
@@ -1,9 +1,24 @@
 package monime
 
-import "errors"
+import "github.com/ose-micro/monime/common"
+
+// APIError is returned for any response with status >= 400. Use errors.As to
+// inspect it, or errors.Is with the sentinels below to branch on its class.
+type APIError = common.APIError
+
+// FieldError is a validation failure the API reported for a single field.
+type FieldError = common.FieldError
+
+// ValidationError is returned, without sending the request, when a command
+// fails client-side validation. Its Fields carry JSON paths such as
+// lineItems[0].price.value.
+type ValidationError = common.ValidationError
 
 var (
-	ErrUnauthorized = errors.New("monime: unauthorized")
-	ErrBadRequest   = errors.New("monime: bad request")
-	ErrServerError  = errors.New("monime: internal server error")
-)
\ No newline at end of file
+	ErrUnauthorized = common.ErrUnauthorized
+	ErrBadRequest   = common.ErrBadRequest
+	ErrNotFound     = common.ErrNotFound
+	ErrConflict     = common.ErrConflict
+	ErrRateLimited  = common.ErrRateLimited
+	ErrServerError  = common.ErrServerError
+)
@@ -8,6 +8,7 @@ import (
 	"github.com/ose-micro/core/tracing"
 	"github.com/ose-micro/core/utils"
 	"github.com/ose-micro/monime"
+	"github.com/ose-micro/monime/money"
 	"github.com/ose-micro/monime/services/checkout"
 )
 
@@ -34,21 +35,18 @@ func main() {
 	mme := monime.New(config, log, tracer)
 
 	ac, err := mme.Services().Checkout.Create(context.Background(), &checkout.CreateCommand{
-		Name:        "Help Ishmael",
-		Description: "On the night of July 10th, around 2am, fire tore through a compound inside Wellington. The whole area was dark, and a candle that was left unattended started the fire. Aunty Ramatu, a well-known akara seller, lost everything.",
-		CancelURL:   "https://example.com/cancel",
-		SuccessURL: "https://example.com/success",
-		Reference: utils.GenerateUUID(),
+		Name:               "Help Ishmael",
+		Description:        "On the night of July 10th, around 2am, fire tore through a compound inside Wellington. The whole area was dark, and a candle that was left unattended started the fire. Aunty Ramatu, a well-known akara seller, lost everything.",
+		CancelURL:          "https://example.com/cancel",
+		SuccessURL:         "https://example.com/success",
+		Reference:          utils.GenerateUUID(),
 		FinancialAccountID: "fac-k6CqF5HqTWmgr6DgfnMQphu818F",
 		LineItems: []checkout.Item{
 			{
-				Type: "custom",
-				Name: "Help Ishmael",
+				Type:     checkout.ItemCustom,
+				Name:     "Help Ishmael",
 				Quantity: 1,
-				Price: checkout.ItemPrice{
-					Currency: "SLE",
-					Value: 2 * 100,
-				},
+				Price:    money.MustParse("SLE", "2.00"),
 			},
 		},
 	})
@@ -0,0 +1,15 @@
+package monime
+
+import (
+	"context"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// WithIdempotencyKey returns a context whose mutating request is sent with
+// key as its Idempotency-Key, overriding the key the SDK would derive from
+// the command's Reference. An IdempotencyKey set on the command itself still
+// takes precedence.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return common.WithIdempotencyKey(ctx, key)
+}
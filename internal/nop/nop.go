@@ -0,0 +1,38 @@
+// Package nop provides a logger.Logger and tracing.Tracer that discard
+// everything, for tests that need to build clients, services and handlers.
+package nop
+
+import (
+	"context"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// Logger discards every entry.
+type Logger struct{}
+
+func (Logger) Info(string, ...any)  {}
+func (Logger) Warn(string, ...any)  {}
+func (Logger) Error(string, ...any) {}
+func (Logger) Debug(string, ...any) {}
+func (Logger) Fatal(string, ...any) {}
+func (Logger) Panic(string, ...any) {}
+func (Logger) Zap() *zap.Logger     { return zap.NewNop() }
+
+// Tracer starts non-recording spans.
+type Tracer struct{}
+
+func (Tracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return noop.NewTracerProvider().Tracer("").Start(ctx, name, opts...)
+}
+
+func (Tracer) Shutdown(context.Context) error { return nil }
+
+var (
+	_ logger.Logger  = Logger{}
+	_ tracing.Tracer = Tracer{}
+)
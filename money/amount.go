@@ -0,0 +1,218 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrOverflow         = errors.New("money: amount overflows int64")
+	ErrInvalidAmount    = errors.New("money: invalid amount")
+)
+
+// Amount is a value in the minor units of Currency, e.g. {SLE, 250} is
+// Le 2.50. This is the shape Monime uses on the wire.
+type Amount struct {
+	Currency string `json:"currency"`
+	Value    int64  `json:"value"`
+}
+
+// New returns an amount of minor units.
+func New(currency string, minor int64) Amount {
+	return Amount{Currency: strings.ToUpper(currency), Value: minor}
+}
+
+// Parse reads a decimal major-unit string such as "2.50" into an Amount,
+// rejecting more decimal places than the currency has.
+func Parse(currency, s string) (Amount, error) {
+	units := CurrencyOf(currency).MinorUnits
+
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(units)), nil)))
+	if !r.IsInt() {
+		return Amount{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, units)
+	}
+
+	if !r.Num().IsInt64() {
+		return Amount{}, ErrOverflow
+	}
+
+	return New(currency, r.Num().Int64()), nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants
+// and tests.
+func MustParse(currency, s string) Amount {
+	a, err := Parse(currency, s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// IsZero reports whether the value is zero.
+func (a Amount) IsZero() bool {
+	return a.Value == 0
+}
+
+// IsPositive reports whether the value is greater than zero.
+func (a Amount) IsPositive() bool {
+	return a.Value > 0
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return Amount{}, err
+	}
+
+	if (b.Value > 0 && a.Value > math.MaxInt64-b.Value) || (b.Value < 0 && a.Value < math.MinInt64-b.Value) {
+		return Amount{}, ErrOverflow
+	}
+
+	return Amount{Currency: a.Currency, Value: a.Value + b.Value}, nil
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b.Value == math.MinInt64 {
+		return Amount{}, ErrOverflow
+	}
+	return a.Add(Amount{Currency: b.Currency, Value: -b.Value})
+}
+
+// Multiply returns a * n, e.g. a unit price times a quantity.
+func (a Amount) Multiply(n int64) (Amount, error) {
+	if a.Value == 0 || n == 0 {
+		return Amount{Currency: a.Currency}, nil
+	}
+
+	v := a.Value * n
+	if v/n != a.Value || (a.Value == -1 && n == math.MinInt64) || (n == -1 && a.Value == math.MinInt64) {
+		return Amount{}, ErrOverflow
+	}
+
+	return Amount{Currency: a.Currency, Value: v}, nil
+}
+
+// Allocate splits a by ratios without losing minor units: the remainder
+// left by integer division is handed out one unit at a time to the first
+// shares.
+func (a Amount) Allocate(ratios ...int64) ([]Amount, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("%w: negative ratio %d", ErrInvalidAmount, r)
+		}
+		if total > math.MaxInt64-r {
+			return nil, ErrOverflow
+		}
+		total += r
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("%w: ratios sum to zero", ErrInvalidAmount)
+	}
+
+	shares := make([]Amount, len(ratios))
+	remainder := a.Value
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(a.Value), big.NewInt(r))
+		share.Quo(share, big.NewInt(total))
+		shares[i] = Amount{Currency: a.Currency, Value: share.Int64()}
+		remainder -= shares[i].Value
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Value += step
+		remainder -= step
+	}
+
+	return shares, nil
+}
+
+// Major formats the value in major units with the currency's decimal
+// places, e.g. "2.50".
+func (a Amount) Major() string {
+	units := CurrencyOf(a.Currency).MinorUnits
+
+	sign := ""
+	v := new(big.Int).SetInt64(a.Value)
+	if v.Sign() < 0 {
+		sign = "-"
+		v.Neg(v)
+	}
+
+	digits := v.String()
+	if units == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+// String formats the amount as "<code> <major>", e.g. "SLE 2.50".
+func (a Amount) String() string {
+	return a.Currency + " " + a.Major()
+}
+
+// UnmarshalJSON accepts the value as an integer, or as a number with no
+// fractional part such as 250.0.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Currency string      `json:"currency"`
+		Value    json.Number `json:"value"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	a.Currency = raw.Currency
+	a.Value = 0
+
+	if raw.Value == "" {
+		return nil
+	}
+
+	if v, err := strconv.ParseInt(raw.Value.String(), 10, 64); err == nil {
+		a.Value = v
+		return nil
+	}
+
+	f, ok := new(big.Rat).SetString(raw.Value.String())
+	if !ok || !f.IsInt() || !f.Num().IsInt64() {
+		return fmt.Errorf("%w: value %s is not a whole number of minor units", ErrInvalidAmount, raw.Value)
+	}
+
+	a.Value = f.Num().Int64()
+	return nil
+}
+
+func (a Amount) sameCurrency(b Amount) error {
+	if !strings.EqualFold(a.Currency, b.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.Currency, b.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Amount
+		want    int64
+		wantErr error
+	}{
+		{"sum", New("SLE", 250), New("SLE", 100), 350, nil},
+		{"currency case ignored", New("SLE", 1), Amount{Currency: "sle", Value: 1}, 2, nil},
+		{"negative", New("SLE", 100), New("SLE", -250), -150, nil},
+		{"currency mismatch", New("SLE", 1), New("USD", 1), 0, ErrCurrencyMismatch},
+		{"overflow", New("SLE", math.MaxInt64), New("SLE", 1), 0, ErrOverflow},
+		{"underflow", New("SLE", math.MinInt64), New("SLE", -1), 0, ErrOverflow},
+		{"at the limit", New("SLE", math.MaxInt64-1), New("SLE", 1), math.MaxInt64, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Add(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Value != tt.want {
+				t.Fatalf("Add() = %d, want %d", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSub(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Amount
+		want    int64
+		wantErr error
+	}{
+		{"difference", New("SLE", 250), New("SLE", 100), 150, nil},
+		{"currency mismatch", New("SLE", 1), New("USD", 1), 0, ErrCurrencyMismatch},
+		{"negating min int", New("SLE", 0), New("SLE", math.MinInt64), 0, ErrOverflow},
+		{"underflow", New("SLE", math.MinInt64), New("SLE", 1), 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Sub(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sub() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Value != tt.want {
+				t.Fatalf("Sub() = %d, want %d", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       Amount
+		n       int64
+		want    int64
+		wantErr error
+	}{
+		{"quantity", New("SLE", 250), 3, 750, nil},
+		{"by zero", New("SLE", math.MaxInt64), 0, 0, nil},
+		{"negative", New("SLE", 250), -2, -500, nil},
+		{"overflow", New("SLE", math.MaxInt64/2+1), 2, 0, ErrOverflow},
+		{"large factors", New("SLE", 1<<32), 1 << 32, 0, ErrOverflow},
+		{"min int by minus one", New("SLE", math.MinInt64), -1, 0, ErrOverflow},
+		{"minus one by min int", New("SLE", -1), math.MinInt64, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Multiply(tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Multiply() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Value != tt.want {
+				t.Fatalf("Multiply() = %d, want %d", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		ratios  []int64
+		want    []int64
+		wantErr error
+	}{
+		{"even", 300, []int64{1, 1, 1}, []int64{100, 100, 100}, nil},
+		{"remainder to first shares", 100, []int64{1, 1, 1}, []int64{34, 33, 33}, nil},
+		{"weighted", 1000, []int64{70, 30}, []int64{700, 300}, nil},
+		{"zero ratio gets nothing", 101, []int64{0, 1, 1}, []int64{0, 51, 50}, nil},
+		{"negative amount", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}, nil},
+		{"no overflow in intermediate product", math.MaxInt64, []int64{3, 1}, []int64{6917529027641081856, 2305843009213693951}, nil},
+		{"ratios overflow", 100, []int64{math.MaxInt64, 1}, nil, ErrOverflow},
+		{"negative ratio", 100, []int64{1, -1}, nil, ErrInvalidAmount},
+		{"zero ratios", 100, []int64{0, 0}, nil, ErrInvalidAmount},
+		{"no ratios", 100, nil, nil, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := New("SLE", tt.value).Allocate(tt.ratios...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Allocate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := make([]int64, len(shares))
+			var sum int64
+			for i, s := range shares {
+				if s.Currency != "SLE" {
+					t.Fatalf("share %d currency = %q, want SLE", i, s.Currency)
+				}
+				got[i] = s.Value
+				sum += s.Value
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Allocate() = %v, want %v", got, tt.want)
+			}
+			if sum != tt.value {
+				t.Fatalf("shares sum to %d, want %d", sum, tt.value)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		in       string
+		want     int64
+		wantErr  error
+	}{
+		{"two decimals", "SLE", "2.50", 250, nil},
+		{"one decimal", "SLE", "2.5", 250, nil},
+		{"whole", "SLE", "2", 200, nil},
+		{"surrounding space", "SLE", " 2.50 ", 250, nil},
+		{"negative", "SLE", "-0.05", -5, nil},
+		{"trailing zeros beyond precision", "SLE", "2.500", 250, nil},
+		{"lowercase currency", "sle", "1", 100, nil},
+		{"unregistered currency defaults to two places", "XYZ", "1.23", 123, nil},
+		{"zero decimal currency", "JPY", "250", 250, nil},
+		{"fraction of a minor unit", "SLE", "2.505", 0, ErrInvalidAmount},
+		{"decimals on zero decimal currency", "JPY", "250.5", 0, ErrInvalidAmount},
+		{"not a number", "SLE", "two", 0, ErrInvalidAmount},
+		{"empty", "SLE", "", 0, ErrInvalidAmount},
+		{"overflow", "SLE", "92233720368547758.08", 0, ErrOverflow},
+		{"largest value", "SLE", "92233720368547758.07", math.MaxInt64, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.currency, tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && got.Value != tt.want {
+				t.Fatalf("Parse(%q) = %d, want %d", tt.in, got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestMajor(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{New("SLE", 250), "SLE 2.50"},
+		{New("SLE", 5), "SLE 0.05"},
+		{New("SLE", 0), "SLE 0.00"},
+		{New("SLE", -5), "SLE -0.05"},
+		{New("SLE", math.MinInt64), "SLE -92233720368547758.08"},
+		{New("JPY", 250), "JPY 250"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.amount.String(); got != tt.want {
+				t.Fatalf("String() = %q, want %q", got, tt.want)
+			}
+
+			back, err := Parse(tt.amount.Currency, tt.amount.Major())
+			if err != nil || back != tt.amount {
+				t.Fatalf("Parse(Major()) = %v, %v, want %v", back, err, tt.amount)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr error
+	}{
+		{`{"currency":"SLE","value":250}`, New("SLE", 250), nil},
+		{`{"currency":"SLE","value":250.0}`, New("SLE", 250), nil},
+		{`{"currency":"SLE","value":2.5e2}`, New("SLE", 250), nil},
+		{`{"currency":"SLE"}`, New("SLE", 0), nil},
+		{`{"currency":"SLE","value":250.5}`, Amount{}, ErrInvalidAmount},
+		{`{"currency":"SLE","value":9223372036854775808}`, Amount{}, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Unmarshal() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Fatalf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
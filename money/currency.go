@@ -0,0 +1,52 @@
+package money
+
+import "strings"
+
+// Currency describes an ISO 4217 currency as Monime amounts use it.
+// MinorUnits is the number of decimal places between the major unit and the
+// integer value sent over the wire, e.g. 2 for SLE where 100 = Le 1.00.
+type Currency struct {
+	Code       string
+	MinorUnits int
+	Symbol     string
+}
+
+// DefaultMinorUnits is assumed for currencies missing from the registry.
+const DefaultMinorUnits = 2
+
+var currencies = map[string]Currency{
+	"SLE": {Code: "SLE", MinorUnits: 2, Symbol: "Le"},
+	"SLL": {Code: "SLL", MinorUnits: 2, Symbol: "Le"},
+	"USD": {Code: "USD", MinorUnits: 2, Symbol: "$"},
+	"EUR": {Code: "EUR", MinorUnits: 2, Symbol: "€"},
+	"GBP": {Code: "GBP", MinorUnits: 2, Symbol: "£"},
+	"NGN": {Code: "NGN", MinorUnits: 2, Symbol: "₦"},
+	"GHS": {Code: "GHS", MinorUnits: 2, Symbol: "GH₵"},
+	"LRD": {Code: "LRD", MinorUnits: 2, Symbol: "L$"},
+	"KES": {Code: "KES", MinorUnits: 2, Symbol: "KSh"},
+	"GNF": {Code: "GNF", MinorUnits: 0, Symbol: "FG"},
+	"XOF": {Code: "XOF", MinorUnits: 0, Symbol: "CFA"},
+	"JPY": {Code: "JPY", MinorUnits: 0, Symbol: "¥"},
+}
+
+// LookupCurrency returns the registered metadata for code.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// CurrencyOf returns the metadata for code, falling back to
+// DefaultMinorUnits and no symbol for unregistered codes.
+func CurrencyOf(code string) Currency {
+	if c, ok := LookupCurrency(code); ok {
+		return c
+	}
+	return Currency{Code: strings.ToUpper(code), MinorUnits: DefaultMinorUnits}
+}
+
+// RegisterCurrency adds or replaces a currency in the registry. It is meant
+// to be called during program initialization.
+func RegisterCurrency(c Currency) {
+	c.Code = strings.ToUpper(c.Code)
+	currencies[c.Code] = c
+}
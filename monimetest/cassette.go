@@ -0,0 +1,295 @@
+package monimetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette talks to the network.
+type CassetteMode int
+
+const (
+	// Replay answers every request from the cassette file and fails
+	// requests it has no recording for. It never touches the network.
+	Replay CassetteMode = iota
+	// Record sends every request to the network and rewrites the cassette
+	// file from scratch with what it sees.
+	Record
+	// RecordMissing replays what the cassette has and records the rest.
+	RecordMissing
+)
+
+// Scrubbed stands in for secret header values in recorded interactions.
+const Scrubbed = "[scrubbed]"
+
+// ErrNoInteraction is returned by a Cassette in Replay mode for a request
+// that was never recorded.
+var ErrNoInteraction = errors.New("monimetest: no recorded interaction matches request")
+
+// scrubbedHeaders maps the headers never written to a cassette file to
+// what is written instead.
+var scrubbedHeaders = map[string]string{
+	"Authorization":   "Bearer " + Scrubbed,
+	"Monime-Space-Id": Scrubbed,
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request written to a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as written to a cassette.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette is an http.RoundTripper that records Monime API interactions to
+// a JSON file and replays them offline. Plug it into a client with
+// monime.WithTransport:
+//
+//	cas, err := monimetest.OpenCassette("testdata/checkout.json", monimetest.Replay, nil)
+//	client := monime.New(conf, log, tracer, monime.WithTransport(cas))
+//
+// Requests are matched by method, path, query and body; the host, headers
+// and Idempotency-Key are ignored so recordings made against the sandbox
+// replay against any base URL and with generated keys. Identical requests
+// replay their recordings in order, and the last one is repeated once they
+// run out, which keeps polling loops working. The Authorization token and
+// Monime-Space-Id are scrubbed before anything is written to disk.
+type Cassette struct {
+	path string
+	mode CassetteMode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// OpenCassette loads the cassette at path. next carries requests that are
+// recorded; nil means http.DefaultTransport. In Replay mode the file must
+// exist, in Record mode it is ignored and overwritten.
+func OpenCassette(path string, mode CassetteMode, next http.RoundTripper) (*Cassette, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	c := &Cassette{path: path, mode: mode, next: next}
+	if mode == Record {
+		return c, nil
+	}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && mode == RecordMissing:
+		return c, nil
+	case err != nil:
+		return nil, fmt.Errorf("monimetest: open cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("monimetest: decode cassette %s: %w", path, err)
+	}
+
+	c.interactions = file.Interactions
+	c.used = make([]bool, len(file.Interactions))
+
+	return c, nil
+}
+
+// Interactions returns the recorded interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query().Encode()
+
+	if c.mode != Record {
+		if in, ok := c.find(req.Method, req.URL.Path, query, body); ok {
+			return in.Response.response(req), nil
+		}
+		if c.mode == Replay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+		}
+	}
+
+	res, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  query,
+			Header: scrub(req.Header),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			Status: res.StatusCode,
+			Header: scrub(res.Header),
+			Body:   string(resBody),
+		},
+	}
+
+	if err := c.record(in); err != nil {
+		return nil, err
+	}
+
+	return in.Response.response(req), nil
+}
+
+func (c *Cassette) find(method, path, query string, body []byte) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, in := range c.interactions {
+		r := in.Request
+		if r.Method != method || r.Path != path || r.Query != query || !sameBody([]byte(r.Body), body) {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return in, true
+		}
+		last = i
+	}
+
+	if last < 0 {
+		return Interaction{}, false
+	}
+	return c.interactions[last], true
+}
+
+// record appends in and rewrites the cassette file so a test that fails
+// half way still leaves what it recorded behind.
+func (c *Cassette) record(in Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, in)
+	c.used = append(c.used, true)
+
+	b, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("monimetest: save cassette: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("monimetest: save cassette: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("monimetest: save cassette: %w", err)
+	}
+
+	return nil
+}
+
+func (r RecordedResponse) response(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// readBody drains req.Body and returns it with a clone of req carrying an
+// identical reader, so the request can still be sent without modifying the
+// caller's request as http.RoundTripper forbids.
+func readBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(b))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	return clone, b, nil
+}
+
+// sameBody compares JSON bodies ignoring insignificant whitespace, and
+// anything else byte for byte.
+func sameBody(a, b []byte) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) == nil && json.Compact(&cb, b) == nil {
+		return bytes.Equal(ca.Bytes(), cb.Bytes())
+	}
+	return bytes.Equal(a, b)
+}
+
+func scrub(h http.Header) http.Header {
+	h = h.Clone()
+	for key, value := range scrubbedHeaders {
+		if h.Get(key) != "" {
+			h.Set(key, value)
+		}
+	}
+	return h
+}
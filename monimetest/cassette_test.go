@@ -0,0 +1,220 @@
+package monimetest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ose-micro/monime"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/internal/nop"
+	"github.com/ose-micro/monime/monimetest"
+	"github.com/ose-micro/monime/services/financial_accounts"
+)
+
+// offline fails the test if a replaying cassette reaches for the network.
+type offline struct{ t *testing.T }
+
+func (o offline) RoundTrip(req *http.Request) (*http.Response, error) {
+	o.t.Errorf("cassette sent %s %s to the network", req.Method, req.URL)
+	return nil, errors.New("network disabled")
+}
+
+func accountsThrough(conf *monime.Config, cas *monimetest.Cassette) financial_accounts.Service {
+	return monime.New(conf, nop.Logger{}, nop.Tracer{}, monime.WithTransport(cas)).Services().FinancialAccount
+}
+
+// recordSession records a create, a get, an update and a second get of one
+// financial account and returns the cassette path and the account ID.
+func recordSession(t *testing.T) (string, string) {
+	t.Helper()
+
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "accounts.json")
+	cas, err := monimetest.OpenCassette(path, monimetest.Record, nil)
+	if err != nil {
+		t.Fatalf("OpenCassette() = %v", err)
+	}
+
+	ctx := context.Background()
+	accounts := accountsThrough(srv.Config(), cas)
+
+	created, err := accounts.Create(ctx, &financial_accounts.CreateCommand{Name: "Wallet", Currency: "SLE", Reference: "ref-1"})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	id := created.Result.Id
+
+	if _, err := accounts.Get(ctx, id); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if _, err := accounts.Update(ctx, &financial_accounts.UpdateCommand{Id: id, Name: "Savings"}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if _, err := accounts.Get(ctx, id); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+
+	return path, id
+}
+
+func TestCassetteScrubsSecrets(t *testing.T) {
+	path, _ := recordSession(t)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() = %v", err)
+	}
+
+	for _, secret := range []string{monimetest.Token, monimetest.Space} {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("cassette file contains %q", secret)
+		}
+	}
+
+	var file struct {
+		Interactions []monimetest.Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(b, &file); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+
+	if len(file.Interactions) != 4 {
+		t.Fatalf("cassette holds %d interactions, want 4", len(file.Interactions))
+	}
+	for i, in := range file.Interactions {
+		if got := in.Request.Header.Get("Authorization"); got != "Bearer "+monimetest.Scrubbed {
+			t.Errorf("interaction %d Authorization = %q", i, got)
+		}
+		if got := in.Request.Header.Get("Monime-Space-Id"); got != monimetest.Scrubbed {
+			t.Errorf("interaction %d Monime-Space-Id = %q", i, got)
+		}
+	}
+}
+
+func TestCassetteReplaysOffline(t *testing.T) {
+	path, id := recordSession(t)
+
+	cas, err := monimetest.OpenCassette(path, monimetest.Replay, offline{t})
+	if err != nil {
+		t.Fatalf("OpenCassette() = %v", err)
+	}
+
+	// recordings replay against any host
+	conf := &monime.Config{
+		BaseURL:    "http://monime.invalid",
+		Access:     monimetest.Token,
+		Space:      monimetest.Space,
+		Version:    "caph.2025-06-20",
+		TimeoutSec: 5,
+		Retry:      monime.RetryConfig{MaxAttempts: 1},
+	}
+
+	ctx := context.Background()
+	accounts := accountsThrough(conf, cas)
+
+	created, err := accounts.Create(ctx, &financial_accounts.CreateCommand{Name: "Wallet", Currency: "SLE", Reference: "ref-1"})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	if created.Result.Id != id {
+		t.Fatalf("Create() replayed account %q, want %q", created.Result.Id, id)
+	}
+
+	// identical requests replay in recorded order, then repeat the last
+	for i, want := range []string{"Wallet", "Savings", "Savings"} {
+		got, err := accounts.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get() %d = %v", i+1, err)
+		}
+		if got.Result.Name != want {
+			t.Fatalf("Get() %d name = %q, want %q", i+1, got.Result.Name, want)
+		}
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"other path", func() error {
+			_, err := accounts.Get(ctx, "fa-unknown")
+			return err
+		}},
+		{"other query", func() error {
+			_, err := accounts.List(ctx, &financial_accounts.ListParams{ListOptions: common.ListOptions{Limit: 5}})
+			return err
+		}},
+		{"other body", func() error {
+			_, err := accounts.Update(ctx, &financial_accounts.UpdateCommand{Id: id, Name: "Other"})
+			return err
+		}},
+		{"other method", func() error {
+			req, _ := http.NewRequest(http.MethodDelete, conf.BaseURL+"/financial-accounts/"+id, nil)
+			_, err := cas.RoundTrip(req)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, monimetest.ErrNoInteraction) {
+				t.Fatalf("error = %v, want %v", err, monimetest.ErrNoInteraction)
+			}
+		})
+	}
+}
+
+func TestCassetteRecordMissing(t *testing.T) {
+	path, id := recordSession(t)
+
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	cas, err := monimetest.OpenCassette(path, monimetest.RecordMissing, nil)
+	if err != nil {
+		t.Fatalf("OpenCassette() = %v", err)
+	}
+
+	ctx := context.Background()
+	accounts := accountsThrough(srv.Config(), cas)
+
+	if _, err := accounts.Get(ctx, id); err != nil {
+		t.Fatalf("recorded Get() = %v", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("recorded request reached the server %d times", n)
+	}
+
+	if _, err := accounts.List(ctx, nil); err != nil {
+		t.Fatalf("new List() = %v", err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Fatalf("new request reached the server %d times, want 1", n)
+	}
+
+	reopened, err := monimetest.OpenCassette(path, monimetest.Replay, offline{t})
+	if err != nil {
+		t.Fatalf("OpenCassette() = %v", err)
+	}
+	if n := len(reopened.Interactions()); n != 5 {
+		t.Fatalf("cassette holds %d interactions after recording the missing one, want 5", n)
+	}
+}
+
+func TestOpenCassetteMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	if _, err := monimetest.OpenCassette(path, monimetest.Replay, nil); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("OpenCassette(Replay) = %v, want %v", err, os.ErrNotExist)
+	}
+	if _, err := monimetest.OpenCassette(path, monimetest.RecordMissing, nil); err != nil {
+		t.Fatalf("OpenCassette(RecordMissing) = %v, want nil", err)
+	}
+}
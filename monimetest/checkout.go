@@ -0,0 +1,105 @@
+package monimetest
+
+import (
+	"net/http"
+
+	"github.com/ose-micro/monime/services/checkout"
+)
+
+func (s *Server) createCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	var cmd checkout.CreateCommand
+	if !decode(w, r, &cmd) {
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		writeInvalid(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	id := s.nextID("cos")
+	session := checkout.Domain{
+		ID:                 id,
+		Name:               cmd.Name,
+		Description:        cmd.Description,
+		Status:             checkout.StatusPending,
+		CancelURL:          cmd.CancelURL,
+		SuccessURL:         cmd.SuccessURL,
+		CallbackState:      cmd.CallbackState,
+		Reference:          cmd.Reference,
+		RedirectURL:        s.URL + "/checkout/" + id,
+		FinancialAccountID: cmd.FinancialAccountID,
+		LineItems:          checkout.LineItems{Data: cmd.LineItems},
+	}
+	s.sessions = append(s.sessions, session)
+	s.mu.Unlock()
+
+	writeOne(w, http.StatusCreated, session)
+}
+
+func (s *Server) listCheckoutSessions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	items := append([]checkout.Domain(nil), s.sessions...)
+	s.mu.Unlock()
+
+	writePage(w, r, items,
+		func(c checkout.Domain) string { return c.ID },
+		func(c checkout.Domain) bool {
+			return matches(q.Get("status"), string(c.Status)) &&
+				matches(q.Get("reference"), c.Reference) &&
+				matches(q.Get("financialAccountId"), c.FinancialAccountID)
+		},
+	)
+}
+
+func (s *Server) getCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.sessions {
+		if c.ID == r.PathValue("id") {
+			writeOne(w, http.StatusOK, c)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "checkout session not found")
+}
+
+func (s *Server) updateCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	var cmd checkout.UpdateCommand
+	if !decode(w, r, &cmd) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.sessions {
+		if c.ID != r.PathValue("id") {
+			continue
+		}
+
+		if cmd.Name != "" {
+			c.Name = cmd.Name
+		}
+		if cmd.Reference != "" {
+			c.Reference = cmd.Reference
+		}
+		if len(cmd.Metadata) > 0 {
+			c.Metadata = make(map[string]interface{}, len(cmd.Metadata))
+			for k, v := range cmd.Metadata {
+				c.Metadata[k] = v
+			}
+		}
+
+		s.sessions[i] = c
+		writeOne(w, http.StatusOK, c)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "checkout session not found")
+}
@@ -0,0 +1,84 @@
+package monimetest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Failure makes the server answer matching requests with an error instead
+// of handling them.
+type Failure struct {
+	// Method matches any method when empty.
+	Method string
+	// Path matches every path with this prefix; empty matches all paths.
+	Path string
+	// Status is the HTTP status to answer with, e.g. 503.
+	Status int
+	// Reason and Message fill the error envelope.
+	Reason  string
+	Message string
+	// Header is added to the response, e.g. Retry-After.
+	Header http.Header
+	// Times is how many requests fail before the failure is cleared; zero
+	// or less fails every matching request until ClearFailures is called.
+	Times int
+}
+
+// InjectFailure queues f. Failures are matched in the order injected.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &f)
+}
+
+// ClearFailures removes every injected failure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// takeFailure returns the first failure matching r and consumes one of its
+// remaining uses. s.mu must be held.
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (f *Failure) write(w http.ResponseWriter) {
+	for k, v := range f.Header {
+		w.Header()[k] = v
+	}
+
+	reason := f.Reason
+	if reason == "" {
+		reason = strings.ToLower(strings.ReplaceAll(http.StatusText(f.Status), " ", "_"))
+	}
+
+	message := f.Message
+	if message == "" {
+		message = "injected failure"
+	}
+
+	writeError(w, f.Status, reason, message)
+}
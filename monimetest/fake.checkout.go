@@ -0,0 +1,58 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/checkout"
+)
+
+// FakeCheckout is an in-memory checkout.Service.
+type FakeCheckout struct {
+	OnCreate Method[*checkout.CreateCommand, *common.OneResponse[checkout.Domain]]
+	OnGet    Method[string, *common.OneResponse[checkout.Domain]]
+	OnUpdate Method[*checkout.UpdateCommand, *common.OneResponse[checkout.Domain]]
+	OnList   Method[*checkout.ListParams, *common.Response[checkout.Domain]]
+}
+
+// Create implements checkout.Service.
+func (f *FakeCheckout) Create(ctx context.Context, cmd *checkout.CreateCommand) (*common.OneResponse[checkout.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[checkout.Domain])
+}
+
+// Get implements checkout.Service.
+func (f *FakeCheckout) Get(ctx context.Context, id string) (*common.OneResponse[checkout.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[checkout.Domain])
+}
+
+// Update implements checkout.Service.
+func (f *FakeCheckout) Update(ctx context.Context, cmd *checkout.UpdateCommand) (*common.OneResponse[checkout.Domain], error) {
+	return f.OnUpdate.call(ctx, cmd, common.Validate(cmd), one[checkout.Domain])
+}
+
+// List implements checkout.Service.
+func (f *FakeCheckout) List(ctx context.Context, params *checkout.ListParams) (*common.Response[checkout.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[checkout.Domain])
+}
+
+// Iter implements checkout.Service by paging through List.
+func (f *FakeCheckout) Iter(ctx context.Context, params *checkout.ListParams) iter.Seq2[checkout.Domain, error] {
+	var base checkout.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[checkout.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements checkout.Service.
+func (f *FakeCheckout) ListAll(ctx context.Context, params *checkout.ListParams, max int) ([]checkout.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+var _ checkout.Service = (*FakeCheckout)(nil)
@@ -0,0 +1,58 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/financial_accounts"
+)
+
+// FakeFinancialAccounts is an in-memory financial_accounts.Service.
+type FakeFinancialAccounts struct {
+	OnCreate Method[*financial_accounts.CreateCommand, *common.OneResponse[financial_accounts.Domain]]
+	OnGet    Method[string, *common.OneResponse[financial_accounts.Domain]]
+	OnUpdate Method[*financial_accounts.UpdateCommand, *common.OneResponse[financial_accounts.Domain]]
+	OnList   Method[*financial_accounts.ListParams, *common.Response[financial_accounts.Domain]]
+}
+
+// Create implements financial_accounts.Service.
+func (f *FakeFinancialAccounts) Create(ctx context.Context, cmd *financial_accounts.CreateCommand) (*common.OneResponse[financial_accounts.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[financial_accounts.Domain])
+}
+
+// Get implements financial_accounts.Service.
+func (f *FakeFinancialAccounts) Get(ctx context.Context, id string) (*common.OneResponse[financial_accounts.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[financial_accounts.Domain])
+}
+
+// Update implements financial_accounts.Service.
+func (f *FakeFinancialAccounts) Update(ctx context.Context, cmd *financial_accounts.UpdateCommand) (*common.OneResponse[financial_accounts.Domain], error) {
+	return f.OnUpdate.call(ctx, cmd, common.Validate(cmd), one[financial_accounts.Domain])
+}
+
+// List implements financial_accounts.Service.
+func (f *FakeFinancialAccounts) List(ctx context.Context, params *financial_accounts.ListParams) (*common.Response[financial_accounts.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[financial_accounts.Domain])
+}
+
+// Iter implements financial_accounts.Service by paging through List.
+func (f *FakeFinancialAccounts) Iter(ctx context.Context, params *financial_accounts.ListParams) iter.Seq2[financial_accounts.Domain, error] {
+	var base financial_accounts.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[financial_accounts.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements financial_accounts.Service.
+func (f *FakeFinancialAccounts) ListAll(ctx context.Context, params *financial_accounts.ListParams, max int) ([]financial_accounts.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+var _ financial_accounts.Service = (*FakeFinancialAccounts)(nil)
@@ -0,0 +1,46 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/financial_transactions"
+)
+
+// FakeFinancialTransactions is an in-memory financial_transactions.Service.
+type FakeFinancialTransactions struct {
+	OnGet  Method[string, *common.OneResponse[financial_transactions.Domain]]
+	OnList Method[*financial_transactions.ListParams, *common.Response[financial_transactions.Domain]]
+}
+
+// Get implements financial_transactions.Service.
+func (f *FakeFinancialTransactions) Get(ctx context.Context, id string) (*common.OneResponse[financial_transactions.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[financial_transactions.Domain])
+}
+
+// List implements financial_transactions.Service.
+func (f *FakeFinancialTransactions) List(ctx context.Context, params *financial_transactions.ListParams) (*common.Response[financial_transactions.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[financial_transactions.Domain])
+}
+
+// Iter implements financial_transactions.Service by paging through List.
+func (f *FakeFinancialTransactions) Iter(ctx context.Context, params *financial_transactions.ListParams) iter.Seq2[financial_transactions.Domain, error] {
+	var base financial_transactions.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[financial_transactions.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements financial_transactions.Service.
+func (f *FakeFinancialTransactions) ListAll(ctx context.Context, params *financial_transactions.ListParams, max int) ([]financial_transactions.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+var _ financial_transactions.Service = (*FakeFinancialTransactions)(nil)
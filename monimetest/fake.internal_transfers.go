@@ -0,0 +1,52 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/internal_transfers"
+)
+
+// FakeInternalTransfers is an in-memory internal_transfers.Service.
+type FakeInternalTransfers struct {
+	OnCreate Method[*internal_transfers.CreateCommand, *common.OneResponse[internal_transfers.Domain]]
+	OnGet    Method[string, *common.OneResponse[internal_transfers.Domain]]
+	OnList   Method[*internal_transfers.ListParams, *common.Response[internal_transfers.Domain]]
+}
+
+// Create implements internal_transfers.Service.
+func (f *FakeInternalTransfers) Create(ctx context.Context, cmd *internal_transfers.CreateCommand) (*common.OneResponse[internal_transfers.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[internal_transfers.Domain])
+}
+
+// Get implements internal_transfers.Service.
+func (f *FakeInternalTransfers) Get(ctx context.Context, id string) (*common.OneResponse[internal_transfers.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[internal_transfers.Domain])
+}
+
+// List implements internal_transfers.Service.
+func (f *FakeInternalTransfers) List(ctx context.Context, params *internal_transfers.ListParams) (*common.Response[internal_transfers.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[internal_transfers.Domain])
+}
+
+// Iter implements internal_transfers.Service by paging through List.
+func (f *FakeInternalTransfers) Iter(ctx context.Context, params *internal_transfers.ListParams) iter.Seq2[internal_transfers.Domain, error] {
+	var base internal_transfers.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[internal_transfers.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements internal_transfers.Service.
+func (f *FakeInternalTransfers) ListAll(ctx context.Context, params *internal_transfers.ListParams, max int) ([]internal_transfers.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+var _ internal_transfers.Service = (*FakeInternalTransfers)(nil)
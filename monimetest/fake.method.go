@@ -0,0 +1,102 @@
+package monimetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ose-micro/monime/common"
+)
+
+type result[Res any] struct {
+	res Res
+	err error
+}
+
+// Method records the calls made to one method of a fake service and
+// scripts what it returns. Queued results are returned first, in order;
+// after that the function set with Func is called; with neither, the method
+// succeeds with an empty response. Commands and ids are validated before
+// any of this, so invalid input fails with the same *common.ValidationError
+// the real service returns.
+type Method[Req any, Res any] struct {
+	mu    sync.Mutex
+	calls []Req
+	queue []result[Res]
+	fn    func(ctx context.Context, req Req) (Res, error)
+}
+
+// Returns queues a result for the next unscripted call.
+func (m *Method[Req, Res]) Returns(res Res, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.queue = append(m.queue, result[Res]{res: res, err: err})
+}
+
+// Fails queues an error for the next unscripted call.
+func (m *Method[Req, Res]) Fails(err error) {
+	var zero Res
+	m.Returns(zero, err)
+}
+
+// Func handles every call once the queue is empty.
+func (m *Method[Req, Res]) Func(fn func(ctx context.Context, req Req) (Res, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.fn = fn
+}
+
+// Calls returns the arguments of every call so far, in order.
+func (m *Method[Req, Res]) Calls() []Req {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Req(nil), m.calls...)
+}
+
+// Reset forgets recorded calls, queued results and Func.
+func (m *Method[Req, Res]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls, m.queue, m.fn = nil, nil, nil
+}
+
+func (m *Method[Req, Res]) call(ctx context.Context, req Req, invalid error, empty func() Res) (Res, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, req)
+
+	if invalid != nil {
+		m.mu.Unlock()
+		var zero Res
+		return zero, invalid
+	}
+
+	if len(m.queue) > 0 {
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		m.mu.Unlock()
+		return next.res, next.err
+	}
+
+	fn := m.fn
+	m.mu.Unlock()
+
+	if fn != nil {
+		return fn(ctx, req)
+	}
+	return empty(), nil
+}
+
+func one[T any]() *common.OneResponse[T] {
+	return &common.OneResponse[T]{Success: true, Messages: []any{}}
+}
+
+func many[T any]() *common.Response[T] {
+	return &common.Response[T]{Success: true, Messages: []any{}, Result: []T{}}
+}
+
+func none() struct{} {
+	return struct{}{}
+}
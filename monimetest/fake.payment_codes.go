@@ -0,0 +1,65 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/payment_codes"
+)
+
+// FakePaymentCodes is an in-memory payment_codes.Service.
+type FakePaymentCodes struct {
+	OnCreate Method[*payment_codes.CreateCommand, *common.OneResponse[payment_codes.Domain]]
+	OnGet    Method[string, *common.OneResponse[payment_codes.Domain]]
+	OnUpdate Method[*payment_codes.UpdateCommand, *common.OneResponse[payment_codes.Domain]]
+	OnDelete Method[string, struct{}]
+	OnList   Method[*payment_codes.ListParams, *common.Response[payment_codes.Domain]]
+}
+
+// Create implements payment_codes.Service.
+func (f *FakePaymentCodes) Create(ctx context.Context, cmd *payment_codes.CreateCommand) (*common.OneResponse[payment_codes.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[payment_codes.Domain])
+}
+
+// Get implements payment_codes.Service.
+func (f *FakePaymentCodes) Get(ctx context.Context, id string) (*common.OneResponse[payment_codes.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[payment_codes.Domain])
+}
+
+// Update implements payment_codes.Service.
+func (f *FakePaymentCodes) Update(ctx context.Context, cmd *payment_codes.UpdateCommand) (*common.OneResponse[payment_codes.Domain], error) {
+	return f.OnUpdate.call(ctx, cmd, common.Validate(cmd), one[payment_codes.Domain])
+}
+
+// List implements payment_codes.Service.
+func (f *FakePaymentCodes) List(ctx context.Context, params *payment_codes.ListParams) (*common.Response[payment_codes.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[payment_codes.Domain])
+}
+
+// Iter implements payment_codes.Service by paging through List.
+func (f *FakePaymentCodes) Iter(ctx context.Context, params *payment_codes.ListParams) iter.Seq2[payment_codes.Domain, error] {
+	var base payment_codes.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[payment_codes.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements payment_codes.Service.
+func (f *FakePaymentCodes) ListAll(ctx context.Context, params *payment_codes.ListParams, max int) ([]payment_codes.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+// Delete implements payment_codes.Service.
+func (f *FakePaymentCodes) Delete(ctx context.Context, id string) error {
+	_, err := f.OnDelete.call(ctx, id, common.ValidateID(id), none)
+	return err
+}
+
+var _ payment_codes.Service = (*FakePaymentCodes)(nil)
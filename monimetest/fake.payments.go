@@ -0,0 +1,52 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/payments"
+)
+
+// FakePayments is an in-memory payments.Service.
+type FakePayments struct {
+	OnGet    Method[string, *common.OneResponse[payments.Domain]]
+	OnUpdate Method[*payments.UpdateCommand, *common.OneResponse[payments.Domain]]
+	OnList   Method[*payments.ListParams, *common.Response[payments.Domain]]
+}
+
+// Get implements payments.Service.
+func (f *FakePayments) Get(ctx context.Context, id string) (*common.OneResponse[payments.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[payments.Domain])
+}
+
+// Update implements payments.Service.
+func (f *FakePayments) Update(ctx context.Context, cmd *payments.UpdateCommand) (*common.OneResponse[payments.Domain], error) {
+	return f.OnUpdate.call(ctx, cmd, common.Validate(cmd), one[payments.Domain])
+}
+
+// List implements payments.Service.
+func (f *FakePayments) List(ctx context.Context, params *payments.ListParams) (*common.Response[payments.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[payments.Domain])
+}
+
+// Iter implements payments.Service by paging through List.
+func (f *FakePayments) Iter(ctx context.Context, params *payments.ListParams) iter.Seq2[payments.Domain, error] {
+	var base payments.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[payments.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements payments.Service.
+func (f *FakePayments) ListAll(ctx context.Context, params *payments.ListParams, max int) ([]payments.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+var _ payments.Service = (*FakePayments)(nil)
@@ -0,0 +1,59 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/payouts"
+)
+
+// FakePayouts is an in-memory payouts.Service.
+type FakePayouts struct {
+	OnCreate Method[*payouts.CreateCommand, *common.OneResponse[payouts.Domain]]
+	OnGet    Method[string, *common.OneResponse[payouts.Domain]]
+	OnDelete Method[string, struct{}]
+	OnList   Method[*payouts.ListParams, *common.Response[payouts.Domain]]
+}
+
+// Create implements payouts.Service.
+func (f *FakePayouts) Create(ctx context.Context, cmd *payouts.CreateCommand) (*common.OneResponse[payouts.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[payouts.Domain])
+}
+
+// Get implements payouts.Service.
+func (f *FakePayouts) Get(ctx context.Context, id string) (*common.OneResponse[payouts.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[payouts.Domain])
+}
+
+// List implements payouts.Service.
+func (f *FakePayouts) List(ctx context.Context, params *payouts.ListParams) (*common.Response[payouts.Domain], error) {
+	return f.OnList.call(ctx, params, nil, many[payouts.Domain])
+}
+
+// Iter implements payouts.Service by paging through List.
+func (f *FakePayouts) Iter(ctx context.Context, params *payouts.ListParams) iter.Seq2[payouts.Domain, error] {
+	var base payouts.ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[payouts.Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements payouts.Service.
+func (f *FakePayouts) ListAll(ctx context.Context, params *payouts.ListParams, max int) ([]payouts.Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+// Delete implements payouts.Service.
+func (f *FakePayouts) Delete(ctx context.Context, id string) error {
+	_, err := f.OnDelete.call(ctx, id, common.ValidateID(id), none)
+	return err
+}
+
+var _ payouts.Service = (*FakePayouts)(nil)
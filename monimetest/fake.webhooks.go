@@ -0,0 +1,59 @@
+package monimetest
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/webhooks"
+)
+
+// FakeWebhooks is an in-memory webhooks.Service.
+type FakeWebhooks struct {
+	OnCreate Method[*webhooks.CreateCommand, *common.OneResponse[webhooks.Domain]]
+	OnGet    Method[string, *common.OneResponse[webhooks.Domain]]
+	OnUpdate Method[*webhooks.UpdateCommand, *common.OneResponse[webhooks.Domain]]
+	OnDelete Method[string, struct{}]
+	OnList   Method[*common.ListOptions, *common.Response[webhooks.Domain]]
+}
+
+// Create implements webhooks.Service.
+func (f *FakeWebhooks) Create(ctx context.Context, cmd *webhooks.CreateCommand) (*common.OneResponse[webhooks.Domain], error) {
+	return f.OnCreate.call(ctx, cmd, common.Validate(cmd), one[webhooks.Domain])
+}
+
+// Get implements webhooks.Service.
+func (f *FakeWebhooks) Get(ctx context.Context, id string) (*common.OneResponse[webhooks.Domain], error) {
+	return f.OnGet.call(ctx, id, common.ValidateID(id), one[webhooks.Domain])
+}
+
+// Update implements webhooks.Service.
+func (f *FakeWebhooks) Update(ctx context.Context, cmd *webhooks.UpdateCommand) (*common.OneResponse[webhooks.Domain], error) {
+	return f.OnUpdate.call(ctx, cmd, common.Validate(cmd), one[webhooks.Domain])
+}
+
+// List implements webhooks.Service.
+func (f *FakeWebhooks) List(ctx context.Context, opts *common.ListOptions) (*common.Response[webhooks.Domain], error) {
+	return f.OnList.call(ctx, opts, nil, many[webhooks.Domain])
+}
+
+// Iter implements webhooks.Service by paging through List.
+func (f *FakeWebhooks) Iter(ctx context.Context, opts *common.ListOptions) iter.Seq2[webhooks.Domain, error] {
+	return common.Paginate(ctx, opts, func(ctx context.Context, opts *common.ListOptions) (*common.Response[webhooks.Domain], error) {
+		call := *opts // each recorded call keeps its own cursor
+		return f.List(ctx, &call)
+	})
+}
+
+// ListAll implements webhooks.Service.
+func (f *FakeWebhooks) ListAll(ctx context.Context, opts *common.ListOptions, max int) ([]webhooks.Domain, error) {
+	return common.Collect(f.Iter(ctx, opts), max)
+}
+
+// Delete implements webhooks.Service.
+func (f *FakeWebhooks) Delete(ctx context.Context, id string) error {
+	_, err := f.OnDelete.call(ctx, id, common.ValidateID(id), none)
+	return err
+}
+
+var _ webhooks.Service = (*FakeWebhooks)(nil)
@@ -0,0 +1,62 @@
+package monimetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/monimetest"
+	"github.com/ose-micro/monime/services/payouts"
+)
+
+func TestFakeIterReiterates(t *testing.T) {
+	fakes := monimetest.NewFakes()
+
+	pages := map[string]*common.Response[payouts.Domain]{
+		"":      {Result: []payouts.Domain{{ID: "pyt-1"}, {ID: "pyt-2"}}, Pagination: common.PaginationInfo{Next: "pyt-2"}},
+		"pyt-2": {Result: []payouts.Domain{{ID: "pyt-3"}}},
+	}
+	fakes.Payout.OnList.Func(func(_ context.Context, params *payouts.ListParams) (*common.Response[payouts.Domain], error) {
+		return pages[params.After], nil
+	})
+
+	seq := fakes.Payout.Iter(context.Background(), &payouts.ListParams{ListOptions: common.ListOptions{Limit: 2}})
+	for i := range 2 {
+		var ids []string
+		for payout, err := range seq {
+			if err != nil {
+				t.Fatalf("range %d: Iter() = %v", i+1, err)
+			}
+			ids = append(ids, payout.ID)
+		}
+
+		if len(ids) != 3 {
+			t.Fatalf("range %d: Iter() = %v, want pyt-1 to pyt-3", i+1, ids)
+		}
+	}
+
+	calls := fakes.Payout.OnList.Calls()
+	if len(calls) != 4 {
+		t.Fatalf("List called %d times, want 4", len(calls))
+	}
+	for _, params := range calls {
+		if params.Limit != 2 {
+			t.Fatalf("List called with limit %d, want 2", params.Limit)
+		}
+	}
+}
+
+func TestFakeValidatesCommands(t *testing.T) {
+	fakes := monimetest.NewFakes()
+
+	_, err := fakes.Payout.Create(context.Background(), &payouts.CreateCommand{})
+
+	var invalid *common.ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Create() error = %v, want *common.ValidationError", err)
+	}
+	if len(fakes.Payout.OnCreate.Calls()) != 1 {
+		t.Fatalf("invalid call not recorded")
+	}
+}
@@ -0,0 +1,44 @@
+package monimetest
+
+import "github.com/ose-micro/monime/services"
+
+// Fakes bundles a fake for every service, for code that takes the
+// *services.Service returned by monime.Monime.Services.
+type Fakes struct {
+	FinancialAccount     *FakeFinancialAccounts
+	Checkout             *FakeCheckout
+	PaymentCode          *FakePaymentCodes
+	Payout               *FakePayouts
+	InternalTransfer     *FakeInternalTransfers
+	FinancialTransaction *FakeFinancialTransactions
+	Payment              *FakePayments
+	Webhook              *FakeWebhooks
+}
+
+// NewFakes returns a fresh set of fakes.
+func NewFakes() *Fakes {
+	return &Fakes{
+		FinancialAccount:     &FakeFinancialAccounts{},
+		Checkout:             &FakeCheckout{},
+		PaymentCode:          &FakePaymentCodes{},
+		Payout:               &FakePayouts{},
+		InternalTransfer:     &FakeInternalTransfers{},
+		FinancialTransaction: &FakeFinancialTransactions{},
+		Payment:              &FakePayments{},
+		Webhook:              &FakeWebhooks{},
+	}
+}
+
+// Services wires the fakes into a *services.Service.
+func (f *Fakes) Services() *services.Service {
+	return &services.Service{
+		FinancialAccount:     f.FinancialAccount,
+		Checkout:             f.Checkout,
+		PaymentCode:          f.PaymentCode,
+		Payout:               f.Payout,
+		InternalTransfer:     f.InternalTransfer,
+		FinancialTransaction: f.FinancialTransaction,
+		Payment:              f.Payment,
+		Webhook:              f.Webhook,
+	}
+}
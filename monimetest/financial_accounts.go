@@ -0,0 +1,94 @@
+package monimetest
+
+import (
+	"net/http"
+
+	"github.com/ose-micro/monime/money"
+	"github.com/ose-micro/monime/services/financial_accounts"
+)
+
+func (s *Server) createFinancialAccount(w http.ResponseWriter, r *http.Request) {
+	var cmd financial_accounts.CreateCommand
+	if !decode(w, r, &cmd) {
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		writeInvalid(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	account := financial_accounts.Domain{
+		Id:        s.nextID("fac"),
+		Name:      cmd.Name,
+		Currency:  cmd.Currency,
+		Reference: cmd.Reference,
+		Balance:   &financial_accounts.Balance{Available: money.New(cmd.Currency, 0)},
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	s.accounts = append(s.accounts, account)
+	s.mu.Unlock()
+
+	writeOne(w, http.StatusCreated, account)
+}
+
+func (s *Server) listFinancialAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	items := append([]financial_accounts.Domain(nil), s.accounts...)
+	s.mu.Unlock()
+
+	writePage(w, r, items,
+		func(a financial_accounts.Domain) string { return a.Id },
+		func(a financial_accounts.Domain) bool {
+			return matches(q.Get("reference"), a.Reference) && matches(q.Get("currency"), a.Currency)
+		},
+	)
+}
+
+func (s *Server) getFinancialAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		if a.Id == r.PathValue("id") {
+			writeOne(w, http.StatusOK, a)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "financial account not found")
+}
+
+func (s *Server) updateFinancialAccount(w http.ResponseWriter, r *http.Request) {
+	var cmd financial_accounts.UpdateCommand
+	if !decode(w, r, &cmd) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, a := range s.accounts {
+		if a.Id != r.PathValue("id") {
+			continue
+		}
+
+		if cmd.Name != "" {
+			a.Name = cmd.Name
+		}
+		if cmd.Reference != "" {
+			a.Reference = cmd.Reference
+		}
+		a.UpdatedAt = now()
+
+		s.accounts[i] = a
+		writeOne(w, http.StatusOK, a)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "financial account not found")
+}
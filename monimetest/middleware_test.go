@@ -0,0 +1,52 @@
+package monimetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareReservesIdempotencyKey(t *testing.T) {
+	s := &Server{idempotency: make(map[string]*replay)}
+
+	// a slow handler keeps the first request in flight while the others
+	// arrive, so an unreserved key would let each of them through
+	var calls atomic.Int32
+	h := s.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		writeOne(w, http.StatusCreated, map[string]int32{"call": n})
+	}))
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodPost, "/financial-accounts", strings.NewReader(`{"name":"Wallet"}`))
+			req.Header.Set("Authorization", "Bearer "+Token)
+			req.Header.Set("Monime-Space-Id", Space)
+			req.Header.Set("Idempotency-Key", "key-1")
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			bodies[i] = rec.Body.String()
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("handler ran %d times, want 1", got)
+	}
+	for _, body := range bodies {
+		if body != bodies[0] {
+			t.Fatalf("concurrent requests got different responses: %q and %q", bodies[0], body)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+// Package monimetest provides an in-process fake of the Monime API for
+// tests. It serves financial accounts and checkout sessions from memory
+// through the same envelopes, pagination and idempotency semantics as the
+// real API, so tests exercise rest.Client end to end without network access.
+// For code that depends on the service interfaces rather than the client,
+// NewFakes returns scriptable in-memory implementations of every service,
+// and Cassette replays interactions recorded against the real API.
+package monimetest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ose-micro/monime"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/services/checkout"
+	"github.com/ose-micro/monime/services/financial_accounts"
+)
+
+const (
+	// Space is the space ID the server expects in Monime-Space-Id.
+	Space = "spc-monimetest"
+	// Token is the access token the server accepts.
+	Token = "mon_test_monimetest"
+	// DefaultPageSize is used when a list request has no limit.
+	DefaultPageSize = 50
+)
+
+// Server is a fake Monime API. Create one with NewServer and Close it when
+// the test ends.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	seq         int
+	accounts    []financial_accounts.Domain
+	sessions    []checkout.Domain
+	idempotency map[string]*replay
+	failures    []*Failure
+	requests    []Request
+}
+
+// Request is a request the server received, kept for assertions.
+type Request struct {
+	Method         string
+	Path           string
+	Query          string
+	IdempotencyKey string
+	Body           []byte
+}
+
+// replay is the outcome of the first request made with an idempotency key.
+// done is closed once status and body are set, or once the entry has been
+// dropped because the request failed with a 5xx.
+type replay struct {
+	digest [32]byte
+	done   chan struct{}
+	status int
+	body   []byte
+}
+
+// NewServer starts a fake Monime API on a local port.
+func NewServer() *Server {
+	s := &Server{idempotency: make(map[string]*replay)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /financial-accounts", s.createFinancialAccount)
+	mux.HandleFunc("GET /financial-accounts", s.listFinancialAccounts)
+	mux.HandleFunc("GET /financial-accounts/{id}", s.getFinancialAccount)
+	mux.HandleFunc("PATCH /financial-accounts/{id}", s.updateFinancialAccount)
+	mux.HandleFunc("POST /checkout-sessions", s.createCheckoutSession)
+	mux.HandleFunc("GET /checkout-sessions", s.listCheckoutSessions)
+	mux.HandleFunc("GET /checkout-sessions/{id}", s.getCheckoutSession)
+	mux.HandleFunc("PATCH /checkout-sessions/{id}", s.updateCheckoutSession)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Config returns a monime.Config pointing at the server. Retries are
+// disabled so injected failures surface directly; override Retry to test
+// them.
+func (s *Server) Config() *monime.Config {
+	return &monime.Config{
+		BaseURL:    s.URL,
+		Access:     Token,
+		Space:      Space,
+		Version:    "caph.2025-06-20",
+		TimeoutSec: 5,
+		Retry:      monime.RetryConfig{MaxAttempts: 1},
+	}
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// FinancialAccounts returns the stored financial accounts.
+func (s *Server) FinancialAccounts() []financial_accounts.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]financial_accounts.Domain(nil), s.accounts...)
+}
+
+// CheckoutSessions returns the stored checkout sessions.
+func (s *Server) CheckoutSessions() []checkout.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]checkout.Domain(nil), s.sessions...)
+}
+
+// AddFinancialAccount seeds an account, assigning an ID if it has none.
+func (s *Server) AddFinancialAccount(account financial_accounts.Domain) financial_accounts.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account.Id == "" {
+		account.Id = s.nextID("fac")
+	}
+	s.accounts = append(s.accounts, account)
+	return account
+}
+
+// AddCheckoutSession seeds a checkout session, assigning an ID if it has none.
+func (s *Server) AddCheckoutSession(session checkout.Domain) checkout.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session.ID == "" {
+		session.ID = s.nextID("cos")
+	}
+	s.sessions = append(s.sessions, session)
+	return session
+}
+
+// middleware records requests, checks credentials, applies injected
+// failures and replays responses for repeated idempotency keys. The key is
+// reserved before the request is handled, so a concurrent request with the
+// same key waits for the first one and replays its response instead of
+// creating a second resource.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		key := r.Header.Get("Idempotency-Key")
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method:         r.Method,
+			Path:           r.URL.Path,
+			Query:          r.URL.RawQuery,
+			IdempotencyKey: key,
+			Body:           body,
+		})
+		failure := s.takeFailure(r)
+		s.mu.Unlock()
+
+		if failure != nil {
+			failure.write(w)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "invalid access token")
+			return
+		}
+
+		if r.Header.Get("Monime-Space-Id") != Space {
+			writeError(w, http.StatusForbidden, "forbidden", "unknown space")
+			return
+		}
+
+		if key == "" || r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scope := r.Method + " " + r.URL.Path + " " + key
+		digest := sha256.Sum256(body)
+
+		entry, prev := s.reserve(scope, digest)
+		if prev != nil {
+			if prev.digest != digest {
+				writeError(w, http.StatusConflict, "idempotency_key_reused", "idempotency key was used with a different request body")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(prev.status)
+			w.Write(prev.body)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+
+		s.mu.Lock()
+		if rec.Code < http.StatusInternalServerError {
+			entry.status, entry.body = rec.Code, rec.Body.Bytes()
+		} else {
+			delete(s.idempotency, scope)
+		}
+		s.mu.Unlock()
+		close(entry.done)
+
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	})
+}
+
+// reserve claims scope for a request with the given body digest and returns
+// the new entry. If another request holds scope, reserve waits for it and
+// returns its completed entry instead; a digest mismatch is returned at
+// once. An entry dropped after a 5xx is reserved afresh.
+func (s *Server) reserve(scope string, digest [32]byte) (*replay, *replay) {
+	for {
+		s.mu.Lock()
+		prev, seen := s.idempotency[scope]
+		if !seen {
+			entry := &replay{digest: digest, done: make(chan struct{})}
+			s.idempotency[scope] = entry
+			s.mu.Unlock()
+			return entry, nil
+		}
+		s.mu.Unlock()
+
+		if prev.digest != digest {
+			return nil, prev
+		}
+
+		<-prev.done
+
+		s.mu.Lock()
+		current := s.idempotency[scope]
+		s.mu.Unlock()
+
+		if current == prev {
+			return nil, prev
+		}
+	}
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-monimetest%06d", prefix, s.seq)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeOne[T any](w http.ResponseWriter, status int, result T) {
+	writeJSON(w, status, common.OneResponse[T]{Success: true, Messages: []any{}, Result: result})
+}
+
+// writeInvalid answers a command that failed validation the way the API
+// does, with one detail per field.
+func writeInvalid(w http.ResponseWriter, err error) {
+	var details []common.FieldError
+	var invalid *common.ValidationError
+	if errors.As(err, &invalid) {
+		details = invalid.Fields
+	}
+
+	writeJSON(w, http.StatusBadRequest, map[string]any{
+		"success":  false,
+		"messages": []any{err.Error()},
+		"error": map[string]any{
+			"code":    http.StatusBadRequest,
+			"reason":  "validation_failed",
+			"message": err.Error(),
+			"details": details,
+		},
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, reason, message string) {
+	writeJSON(w, status, map[string]any{
+		"success":  false,
+		"messages": []any{message},
+		"error": map[string]any{
+			"code":    status,
+			"reason":  reason,
+			"message": message,
+		},
+	})
+}
+
+// writePage writes the page of items selected by the limit and after query
+// parameters; after is the ID of the last item of the previous page.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, id func(T) string, keep func(T) bool) {
+	limit := DefaultPageSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+
+	var filtered []T
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	start := 0
+	if after := r.URL.Query().Get("after"); after != "" {
+		start = len(filtered)
+		for i, item := range filtered {
+			if id(item) == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := min(start+limit, len(filtered))
+	page := append([]T{}, filtered[start:end]...)
+
+	next := ""
+	if end < len(filtered) {
+		next = id(filtered[end-1])
+	}
+
+	writeJSON(w, http.StatusOK, common.Response[T]{
+		Success:    true,
+		Messages:   []any{},
+		Result:     page,
+		Pagination: common.PaginationInfo{Count: len(page), Next: next},
+	})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return false
+	}
+	return true
+}
+
+func matches(filter, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
@@ -0,0 +1,213 @@
+package monimetest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ose-micro/monime"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/internal/nop"
+	"github.com/ose-micro/monime/monimetest"
+	"github.com/ose-micro/monime/services/financial_accounts"
+)
+
+func TestFinancialAccountsIterReiterates(t *testing.T) {
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	for _, currency := range []string{"SLE", "USD", "SLE", "SLE", "USD", "SLE", "SLE"} {
+		srv.AddFinancialAccount(financial_accounts.Domain{Name: "Wallet", Currency: currency})
+	}
+
+	accounts := monime.New(srv.Config(), nop.Logger{}, nop.Tracer{}).Services().FinancialAccount
+	params := &financial_accounts.ListParams{ListOptions: common.ListOptions{Limit: 2}, Currency: "SLE"}
+
+	seq := accounts.Iter(context.Background(), params)
+	for i := range 2 {
+		var n int
+		for account, err := range seq {
+			if err != nil {
+				t.Fatalf("range %d: Iter() = %v", i+1, err)
+			}
+			if account.Currency != "SLE" {
+				t.Fatalf("range %d: got a %s account, want only SLE", i+1, account.Currency)
+			}
+			n++
+		}
+
+		if n != 5 {
+			t.Fatalf("range %d: Iter() yielded %d accounts, want 5", i+1, n)
+		}
+	}
+
+	if params.After != "" {
+		t.Fatalf("Iter() changed the caller's cursor to %q", params.After)
+	}
+
+	for _, req := range srv.Requests() {
+		q, _ := url.ParseQuery(req.Query)
+		if q.Get("currency") != "SLE" || q.Get("limit") != "2" {
+			t.Fatalf("page request %s dropped the caller's filters", req.Query)
+		}
+	}
+}
+
+const account = `{"name":"Wallet","currency":"SLE","reference":"ref-1"}`
+
+// send makes a raw request to srv with valid credentials unless headers
+// override them, and returns the status, headers and decoded result ID.
+func send(t *testing.T, srv *monimetest.Server, method, path, body string, headers map[string]string) (int, http.Header, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() = %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+monimetest.Token)
+	req.Header.Set("Monime-Space-Id", monimetest.Space)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+	defer res.Body.Close()
+
+	b, _ := io.ReadAll(res.Body)
+
+	var out struct {
+		Result struct {
+			ID string `json:"id"`
+		} `json:"result"`
+	}
+	json.Unmarshal(b, &out)
+
+	return res.StatusCode, res.Header, out.Result.ID
+}
+
+func TestServerIdempotency(t *testing.T) {
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	key := map[string]string{"Idempotency-Key": "key-1"}
+
+	status, _, first := send(t, srv, http.MethodPost, "/financial-accounts", account, key)
+	if status != http.StatusCreated {
+		t.Fatalf("first POST status = %d, want 201", status)
+	}
+
+	status, header, second := send(t, srv, http.MethodPost, "/financial-accounts", account, key)
+	if status != http.StatusCreated || second != first {
+		t.Fatalf("repeated POST = %d %q, want 201 %q", status, second, first)
+	}
+	if header.Get("Idempotent-Replayed") != "true" {
+		t.Fatal("repeated POST not marked as replayed")
+	}
+
+	other := `{"name":"Other","currency":"SLE","reference":"ref-2"}`
+	if status, _, _ := send(t, srv, http.MethodPost, "/financial-accounts", other, key); status != http.StatusConflict {
+		t.Fatalf("POST reusing the key with another body = %d, want 409", status)
+	}
+
+	if status, _, id := send(t, srv, http.MethodPost, "/financial-accounts", account, map[string]string{"Idempotency-Key": "key-2"}); status != http.StatusCreated || id == first {
+		t.Fatalf("POST with a new key = %d %q, want a new account", status, id)
+	}
+
+	if n := len(srv.FinancialAccounts()); n != 2 {
+		t.Fatalf("server holds %d accounts, want 2", n)
+	}
+}
+
+func TestServerIdempotencyForgetsServerErrors(t *testing.T) {
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	srv.InjectFailure(monimetest.Failure{Method: http.MethodPost, Status: http.StatusServiceUnavailable, Times: 1})
+
+	key := map[string]string{"Idempotency-Key": "key-1"}
+	if status, _, _ := send(t, srv, http.MethodPost, "/financial-accounts", account, key); status != http.StatusServiceUnavailable {
+		t.Fatalf("first POST status = %d, want 503", status)
+	}
+	if status, header, _ := send(t, srv, http.MethodPost, "/financial-accounts", account, key); status != http.StatusCreated || header.Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retried POST = %d, replayed %q, want a fresh 201", status, header.Get("Idempotent-Replayed"))
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure monimetest.Failure
+		method  string
+		path    string
+		want    []int
+	}{
+		{"times", monimetest.Failure{Status: 503, Times: 2}, http.MethodGet, "/financial-accounts", []int{503, 503, 200}},
+		{"until cleared", monimetest.Failure{Status: 500}, http.MethodGet, "/financial-accounts", []int{500, 500, 500}},
+		{"other method", monimetest.Failure{Method: http.MethodPost, Status: 503}, http.MethodGet, "/financial-accounts", []int{200}},
+		{"path prefix", monimetest.Failure{Path: "/checkout-sessions", Status: 503}, http.MethodGet, "/checkout-sessions/cs-1", []int{503}},
+		{"other path", monimetest.Failure{Path: "/checkout-sessions", Status: 503}, http.MethodGet, "/financial-accounts", []int{200}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := monimetest.NewServer()
+			defer srv.Close()
+
+			srv.InjectFailure(tt.failure)
+			for i, want := range tt.want {
+				if status, _, _ := send(t, srv, tt.method, tt.path, "", nil); status != want {
+					t.Fatalf("request %d status = %d, want %d", i+1, status, want)
+				}
+			}
+
+			srv.ClearFailures()
+			if status, _, _ := send(t, srv, http.MethodGet, "/financial-accounts", "", nil); status != http.StatusOK {
+				t.Fatalf("status after ClearFailures = %d, want 200", status)
+			}
+		})
+	}
+}
+
+func TestServerFailureHeaders(t *testing.T) {
+	srv := monimetest.NewServer()
+	defer srv.Close()
+
+	srv.InjectFailure(monimetest.Failure{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"3"}}, Times: 1})
+
+	status, header, _ := send(t, srv, http.MethodGet, "/financial-accounts", "", nil)
+	if status != http.StatusTooManyRequests || header.Get("Retry-After") != "3" {
+		t.Fatalf("injected failure = %d with Retry-After %q, want 429 with 3", status, header.Get("Retry-After"))
+	}
+}
+
+func TestServerCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"valid", nil, http.StatusOK},
+		{"wrong token", map[string]string{"Authorization": "Bearer mon_test_other"}, http.StatusUnauthorized},
+		{"no token", map[string]string{"Authorization": ""}, http.StatusUnauthorized},
+		{"wrong space", map[string]string{"Monime-Space-Id": "spc-other"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := monimetest.NewServer()
+			defer srv.Close()
+
+			if status, _, _ := send(t, srv, http.MethodGet, "/financial-accounts", "", tt.headers); status != tt.want {
+				t.Fatalf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package monime
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ose-micro/monime/rest"
+)
+
+// Option customizes the client built by New. Options are applied after
+// Config, so they take precedence over it.
+type Option = rest.Option
+
+// WithHTTPClient sends requests through hc, e.g. one with a proxy, custom
+// TLS config or tuned connection pool.
+func WithHTTPClient(hc *http.Client) Option {
+	return rest.WithHTTPClient(hc)
+}
+
+// WithTransport replaces the RoundTripper used to send requests.
+func WithTransport(rt http.RoundTripper) Option {
+	return rest.WithTransport(rt)
+}
+
+// WithTimeout overrides Config.TimeoutSec.
+func WithTimeout(timeout time.Duration) Option {
+	return rest.WithTimeout(timeout)
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return rest.WithUserAgent(userAgent)
+}
+
+// WithBaseURL overrides Config.BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return rest.WithBaseURL(baseURL)
+}
+
+// WithRetryPolicy overrides Config.Retry.
+func WithRetryPolicy(policy rest.RetryPolicy) Option {
+	return rest.WithRetryPolicy(policy)
+}
+
+// WithMiddleware registers middlewares on the client; see rest.Middleware.
+func WithMiddleware(middlewares ...rest.Middleware) Option {
+	return rest.WithMiddleware(middlewares...)
+}
+
+// WithRateLimiter limits requests to the configured space using limiter,
+// which may be shared with other clients. It applies in addition to
+// Config.RateLimit.
+func WithRateLimiter(limiter *rest.RateLimiter) Option {
+	return rest.WithRateLimiter(limiter)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ose-micro/core/logger"
 	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -19,19 +20,22 @@ import (
 )
 
 type Client struct {
-	baseURL    string
-	access     string
-	version    string
-	space      string
-	timeoutSec int
-	client     *http.Client
-	log        logger.Logger
-	tracer     tracing.Tracer
+	baseURL     string
+	access      string
+	version     string
+	space       string
+	timeoutSec  int
+	userAgent   string
+	client      *http.Client
+	retry       RetryPolicy
+	middlewares []Middleware
+	log         logger.Logger
+	tracer      tracing.Tracer
 }
 
 func New(baseURL, access, space, version string, timeout int, log logger.Logger,
-	tracer tracing.Tracer) *Client {
-	return &Client{
+	tracer tracing.Tracer, opts ...Option) *Client {
+	c := &Client{
 		baseURL:    baseURL,
 		access:     access,
 		space:      space,
@@ -40,130 +44,45 @@ func New(baseURL, access, space, version string, timeout int, log logger.Logger,
 		log:        log,
 		tracer:     tracer,
 		client:     &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		userAgent:  DefaultUserAgent,
+		retry:      DefaultRetryPolicy(),
 	}
-}
-
-func (c *Client) Get(ctx context.Context, path string, body any, unmarshal func([]byte) (any, error)) (any, error) {
-	var BUF io.Reader
-	METHOD := "GET"
-	TOKEN := fmt.Sprintf("Bearer %s", c.access)
-
-	ctx, span := c.tracer.Start(ctx, "HttpClient.GET", trace.WithAttributes(
-		attribute.String("method", METHOD),
-		attribute.String("path", path),
-	))
-	defer span.End()
-
-	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
-
-	// Marshal body if present
-	if body != nil {
-		v := reflect.ValueOf(body)
-		if v.Kind() == reflect.Ptr && v.IsNil() {
-			c.log.Error("request body is nil",
-				zap.String("trace_id", traceId),
-				zap.String("method", METHOD),
-				zap.String("path", path))
-		}
 
-		b, err := json.Marshal(body)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			c.log.Error("failed to marshal request body",
-				zap.String("trace_id", traceId),
-				zap.Error(err),
-			)
-			return nil, err
-		}
-		BUF = bytes.NewBuffer(b)
-	}
-
-	URL := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, METHOD, URL, BUF)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to create HTTP request",
-			zap.String("trace_id", traceId),
-			zap.Error(err),
-		)
-		return nil, err
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", TOKEN)
-	req.Header.Set("Monime-Version", c.version)
-	req.Header.Set("Monime-Space-Id", c.space)
-
-	c.log.Info("starting HTTP request",
-		zap.String("trace_id", traceId),
-		zap.String("method", METHOD),
-		zap.String("path", path),
-	)
-
-	res, err := c.client.Do(req)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("HTTP request failed",
-			zap.String("trace_id", traceId),
-			zap.Error(err),
-		)
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c
+}
 
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to read response body",
-			zap.String("trace_id", traceId),
-			zap.Error(err),
-		)
-		return nil, err
-	}
+func (c *Client) Get(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+	return c.Request(ctx, http.MethodGet, path, body, headers, unmarshal)
+}
 
-	if res.StatusCode >= 400 {
-		err := fmt.Errorf("request to %s failed with status=%d, body=%s", path, res.StatusCode, string(bodyBytes))
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("HTTP response error",
-			zap.String("trace_id", traceId),
-			zap.Int("status_code", res.StatusCode),
-			zap.String("body", string(bodyBytes)),
-		)
-		return nil, err
-	}
+func (c *Client) POST(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+	return c.Request(ctx, http.MethodPost, path, body, headers, unmarshal)
+}
 
-	// Use the provided unmarshal function to decode the response
-	out, err := unmarshal(bodyBytes)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to decode response",
-			zap.String("trace_id", traceId),
-			zap.Error(err),
-		)
-		return nil, err
-	}
+func (c *Client) PUT(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+	return c.Request(ctx, http.MethodPut, path, body, headers, unmarshal)
+}
 
-	c.log.Info("completed HTTP request and decoded response",
-		zap.String("trace_id", traceId),
-		zap.String("method", METHOD),
-		zap.String("path", path),
-	)
+func (c *Client) PATCH(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+	return c.Request(ctx, http.MethodPatch, path, body, headers, unmarshal)
+}
 
-	return &out, nil
+func (c *Client) DELETE(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+	return c.Request(ctx, http.MethodDelete, path, body, headers, unmarshal)
 }
 
-func (c *Client) POST(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
+// Request is the pipeline shared by every verb: it marshals body (or, for
+// GET, encodes it as the query string), sets the Monime headers plus any
+// non-empty extra headers, sends the request with retries, turns status
+// >= 400 into an *common.APIError and hands the response body to unmarshal.
+func (c *Client) Request(ctx context.Context, method, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
 	var buf io.Reader
-	method := "POST"
-	token := fmt.Sprintf("Bearer %s", c.access)
 
-	ctx, span := c.tracer.Start(ctx, "HttpClient.POST", trace.WithAttributes(
+	ctx, span := c.tracer.Start(ctx, fmt.Sprintf("HttpClient.%s", method), trace.WithAttributes(
 		attribute.String("method", method),
 		attribute.String("path", path),
 	))
@@ -171,6 +90,28 @@ func (c *Client) POST(ctx context.Context, path string, body any, headers map[st
 
 	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
 
+	fail := func(msg string, err error, fields ...any) (any, error) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.log.Error(msg, append([]any{
+			zap.String("trace_id", traceId),
+			zap.String("method", method),
+			zap.String("path", path),
+			zap.Error(err),
+		}, fields...)...)
+		return nil, err
+	}
+
+	// GET requests carry no body; list parameters go in the query string
+	if method == http.MethodGet && body != nil {
+		q, ok := body.(Querier)
+		if !ok {
+			return fail("invalid GET request", fmt.Errorf("rest: GET body %T does not implement Querier", body))
+		}
+		path = appendQuery(path, q.Query())
+		body = nil
+	}
+
 	// Marshal body if present
 	if body != nil {
 		v := reflect.ValueOf(body)
@@ -183,35 +124,24 @@ func (c *Client) POST(ctx context.Context, path string, body any, headers map[st
 
 		b, err := json.Marshal(body)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			c.log.Error("failed to marshal request body",
-				zap.String("trace_id", traceId),
-				zap.String("method", method),
-				zap.Error(err),
-			)
-			return nil, err
+			return fail("failed to marshal request body", err)
 		}
-		buf = bytes.NewBuffer(b)
+		buf = bytes.NewReader(b)
 	}
 
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 	req, err := http.NewRequestWithContext(ctx, method, url, buf)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to create HTTP request",
-			zap.String("trace_id", traceId),
-			zap.String("method", method),
-			zap.Error(err),
-		)
-		return nil, err
+		return fail("failed to create HTTP request", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", token)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.access))
 	req.Header.Set("Monime-Version", c.version)
 	req.Header.Set("Monime-Space-Id", c.space)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 
 	for k, v := range headers {
 		if v != "" {
@@ -225,53 +155,30 @@ func (c *Client) POST(ctx context.Context, path string, body any, headers map[st
 		zap.String("path", path),
 	)
 
-	res, err := c.client.Do(req)
+	res, err := c.send(req)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("HTTP request failed",
-			zap.String("trace_id", traceId),
-			zap.String("method", method),
-			zap.Error(err),
-		)
-		return nil, err
+		return fail("HTTP request failed", err)
 	}
 	defer res.Body.Close()
 
+	span.SetAttributes(attribute.Int("status_code", res.StatusCode))
+
 	bodyBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to read response body",
-			zap.String("trace_id", traceId),
-			zap.String("method", method),
-			zap.Error(err),
-		)
-		return nil, err
+		return fail("failed to read response body", err)
 	}
 
 	if res.StatusCode >= 400 {
-		err := fmt.Errorf("request to %s failed with status=%d, body=%s", path, res.StatusCode, string(bodyBytes))
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("HTTP response error",
-			zap.String("trace_id", traceId),
+		return fail("HTTP response error", common.NewAPIError(method, path, res, bodyBytes),
 			zap.Int("status_code", res.StatusCode),
 			zap.String("body", string(bodyBytes)),
 		)
-		return nil, err
 	}
 
 	// Use the provided unmarshal function to decode the response
 	out, err := unmarshal(bodyBytes)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		c.log.Error("failed to decode response",
-			zap.String("trace_id", traceId),
-			zap.Error(err),
-		)
-		return nil, err
+		return fail("failed to decode response", err)
 	}
 
 	c.log.Info("completed HTTP request and decoded response",
@@ -280,159 +187,5 @@ func (c *Client) POST(ctx context.Context, path string, body any, headers map[st
 		zap.String("path", path),
 	)
 
-	return &out, nil
-}
-
-func (c *Client) PUT(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
-	method := "PUT"
-	token := fmt.Sprintf("Bearer %s", c.access)
-	var buf io.Reader
-
-	ctx, span := c.tracer.Start(ctx, "HttpClient.PUT", trace.WithAttributes(
-		attribute.String("method", method),
-		attribute.String("path", path),
-	))
-	defer span.End()
-
-	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
-
-	if body != nil {
-		v := reflect.ValueOf(body)
-		if v.Kind() == reflect.Ptr && v.IsNil() {
-			c.log.Error("request body is nil",
-				zap.String("trace_id", traceId),
-				zap.String("method", method),
-				zap.String("path", path))
-		}
-
-		b, err := json.Marshal(body)
-		if err != nil {
-			span.RecordError(err)
-			c.log.Error("failed to marshal PUT body", zap.String("trace_id", traceId), zap.Error(err))
-			return nil, err
-		}
-		buf = bytes.NewBuffer(b)
-	}
-
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, url, buf)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("failed to create PUT request", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", token)
-	req.Header.Set("Monime-Version", c.version)
-	req.Header.Set("Monime-Space-Id", c.space)
-	for k, v := range headers {
-		if v != "" {
-			req.Header.Set(k, v)
-		}
-	}
-
-	res, err := c.client.Do(req)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("PUT request failed", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("failed to read PUT response", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	if res.StatusCode >= 400 {
-		err := fmt.Errorf("PUT request to %s failed: %d - %s", path, res.StatusCode, string(bodyBytes))
-		span.RecordError(err)
-		c.log.Error("PUT response error", zap.String("trace_id", traceId), zap.String("body", string(bodyBytes)))
-		return nil, err
-	}
-
-	out, err := unmarshal(bodyBytes)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("PUT unmarshal failed", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	return &out, nil
-}
-
-func (c *Client) DELETE(ctx context.Context, path string, body any, headers map[string]string, unmarshal func([]byte) (any, error)) (any, error) {
-	method := "DELETE"
-	token := fmt.Sprintf("Bearer %s", c.access)
-	var buf io.Reader
-
-	ctx, span := c.tracer.Start(ctx, "HttpClient.DELETE", trace.WithAttributes(
-		attribute.String("method", method),
-		attribute.String("path", path),
-	))
-	defer span.End()
-
-	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
-
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			span.RecordError(err)
-			c.log.Error("failed to marshal DELETE body", zap.String("trace_id", traceId), zap.Error(err))
-			return nil, err
-		}
-		buf = bytes.NewBuffer(b)
-	}
-
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, url, buf)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("failed to create DELETE request", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", token)
-	req.Header.Set("Monime-Version", c.version)
-	req.Header.Set("Monime-Space-Id", c.space)
-	for k, v := range headers {
-		if v != "" {
-			req.Header.Set(k, v)
-		}
-	}
-
-	res, err := c.client.Do(req)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("DELETE request failed", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("failed to read DELETE response", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	if res.StatusCode >= 400 {
-		err := fmt.Errorf("DELETE request to %s failed: %d - %s", path, res.StatusCode, string(bodyBytes))
-		span.RecordError(err)
-		c.log.Error("DELETE response error", zap.String("trace_id", traceId), zap.String("body", string(bodyBytes)))
-		return nil, err
-	}
-
-	out, err := unmarshal(bodyBytes)
-	if err != nil {
-		span.RecordError(err)
-		c.log.Error("DELETE unmarshal failed", zap.String("trace_id", traceId), zap.Error(err))
-		return nil, err
-	}
-
-	return &out, nil
+	return out, nil
 }
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// RequestOptions carries per-request settings for Do.
+type RequestOptions struct {
+	// Headers are added to the request; empty values are skipped.
+	Headers map[string]string
+	// Query is appended to the request path.
+	Query url.Values
+}
+
+// Do sends a request through c and decodes the JSON response into a new T.
+// An empty response body leaves T at its zero value.
+func Do[T any](ctx context.Context, c *Client, method, path string, body any, opts *RequestOptions) (*T, error) {
+	var out T
+
+	var headers map[string]string
+	if opts != nil {
+		headers = opts.Headers
+		path = appendQuery(path, opts.Query)
+	}
+
+	unmarshal := func(b []byte) (any, error) {
+		if len(b) == 0 {
+			return out, nil
+		}
+		if err := json.Unmarshal(b, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	if _, err := c.Request(ctx, method, path, body, headers, unmarshal); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
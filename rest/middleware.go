@@ -0,0 +1,38 @@
+package rest
+
+import "net/http"
+
+// RoundTripFunc sends a single HTTP request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to inspect or modify requests and
+// responses, e.g. to add headers, sign requests, record metrics or inject
+// faults. Middlewares run once per attempt, so a retried request passes
+// through them again.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middlewares to the client. The first middleware registered is
+// the outermost one. Use must not be called concurrently with requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// roundTrip sends req through the middleware chain.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.client.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	return next(req)
+}
+
+// SetHeader returns a Middleware that sets key to value on every request,
+// overriding any header the client set itself.
+func SetHeader(key, value string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(key, value)
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultUserAgent is sent unless WithUserAgent overrides it.
+const DefaultUserAgent = "ose-micro-monime-go"
+
+// Option customizes a Client built by New.
+type Option func(*Client)
+
+// WithHTTPClient makes the client send requests through hc, e.g. one with a
+// proxy, custom TLS config or tuned connection pool. Its Timeout is kept.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.client = hc
+		}
+	}
+}
+
+// WithTransport replaces the RoundTripper of the client's http.Client. The
+// http.Client is copied first, so one passed to WithHTTPClient is not
+// modified.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.client
+		hc.Transport = rt
+		c.client = &hc
+	}
+}
+
+// WithTimeout sets the per-attempt timeout of the client's http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.client
+		hc.Timeout = timeout
+		c.client = &hc
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithBaseURL overrides the API base URL, e.g. to point at a sandbox or a
+// local fake.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithMiddleware registers middlewares as Use does.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middlewares...)
+	}
+}
@@ -0,0 +1,26 @@
+package rest
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Querier is implemented by list parameters that encode themselves as URL
+// query values. A Querier passed as the body of a GET request is sent as
+// its query string rather than as JSON.
+type Querier interface {
+	Query() url.Values
+}
+
+// appendQuery adds q to path, keeping any query path already carries.
+func appendQuery(path string, q url.Values) string {
+	encoded := q.Encode()
+	if encoded == "" {
+		return path
+	}
+
+	if strings.Contains(path, "?") {
+		return path + "&" + encoded
+	}
+	return path + "?" + encoded
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// filter is a Querier standing in for a service's ListParams.
+type filter struct {
+	status string
+}
+
+func (f filter) Query() url.Values {
+	q := url.Values{}
+	if f.status != "" {
+		q.Set("status", f.status)
+	}
+	return q
+}
+
+func TestRequestQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		body   any
+		query  url.Values
+		method string
+		want   string
+	}{
+		{"querier", "/payouts", filter{status: "pending"}, nil, http.MethodGet, "status=pending"},
+		{"empty querier", "/payouts", filter{}, nil, http.MethodGet, ""},
+		{"options", "/payouts", nil, url.Values{"limit": {"10"}}, http.MethodGet, "limit=10"},
+		{"querier and options", "/payouts", filter{status: "pending"}, url.Values{"limit": {"10"}}, http.MethodGet, "limit=10&status=pending"},
+		{"path with query", "/payouts?after=pyt-1", nil, url.Values{"limit": {"10"}}, http.MethodGet, "after=pyt-1&limit=10"},
+		{"path with query and querier", "/payouts?after=pyt-1", filter{status: "pending"}, nil, http.MethodGet, "after=pyt-1&status=pending"},
+		{"escaped", "/payouts", nil, url.Values{"reference": {"a&b c"}}, http.MethodGet, "reference=a%26b+c"},
+		{"delete", "/payouts/pyt-1", nil, url.Values{"reason": {"duplicate"}}, http.MethodDelete, "reason=duplicate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{responses: []response{{status: http.StatusOK}}}
+			c := newTestClient(t, rec)
+
+			if _, err := Do[struct{}](context.Background(), c, tt.method, tt.path, tt.body, &RequestOptions{Query: tt.query}); err != nil {
+				t.Fatalf("Do() = %v", err)
+			}
+
+			req := rec.requests[0]
+			if req.query != tt.want {
+				t.Fatalf("server received query %q, want %q", req.query, tt.want)
+			}
+			if req.body != "" {
+				t.Fatalf("server received body %q, want none", req.body)
+			}
+		})
+	}
+}
+
+func TestRequestRejectsGetBody(t *testing.T) {
+	rec := &recorder{responses: []response{{status: http.StatusOK}}}
+	c := newTestClient(t, rec)
+
+	body := map[string]string{"status": "pending"}
+	if _, err := Do[struct{}](context.Background(), c, http.MethodGet, "/payouts", body, nil); err == nil {
+		t.Fatal("Do() = nil, want an error for a GET body that is not a Querier")
+	}
+	if n := rec.attempts(); n != 0 {
+		t.Fatalf("rejected request reached the server %d times", n)
+	}
+}
+
+func TestAppendQuery(t *testing.T) {
+	tests := []struct {
+		path string
+		q    url.Values
+		want string
+	}{
+		{"/payouts", nil, "/payouts"},
+		{"/payouts", url.Values{}, "/payouts"},
+		{"/payouts", url.Values{"limit": {"10"}}, "/payouts?limit=10"},
+		{"/payouts?after=pyt-1", url.Values{"limit": {"10"}}, "/payouts?after=pyt-1&limit=10"},
+		{"/payouts?after=pyt-1", nil, "/payouts?after=pyt-1"},
+		{"/payouts", url.Values{"b": {"2"}, "a": {"1", "3"}}, "/payouts?a=1&a=3&b=2"},
+	}
+
+	for _, tt := range tests {
+		if got := appendQuery(tt.path, tt.q); got != tt.want {
+			t.Errorf("appendQuery(%q, %v) = %q, want %q", tt.path, tt.q, got, tt.want)
+		}
+	}
+}
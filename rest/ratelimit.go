@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// RateLimiter is a client-side token bucket per Monime space. One limiter
+// can be shared by several clients so that everything talking to a space
+// draws from the same budget. It also backs off when Monime answers 429 or
+// reports an exhausted quota in its rate-limit headers.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter allows rate requests per second per space, with bursts of
+// up to burst requests. A rate of zero or less only applies the server's
+// rate-limit feedback.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request to space may be sent. If ctx is done first,
+// or its deadline falls before the request could be sent, Wait fails fast
+// with an error matching common.ErrRateLimited.
+func (l *RateLimiter) Wait(ctx context.Context, space string) error {
+	delay := l.reserve(space)
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && l.now().Add(delay).After(deadline) {
+		l.cancel(space)
+		return fmt.Errorf("rest: space %s needs %s before the next request: %w", space, delay, common.ErrRateLimited)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel(space)
+		return fmt.Errorf("rest: %w: %w", common.ErrRateLimited, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Throttle stops requests to space until the given time.
+func (l *RateLimiter) Throttle(space string, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(space)
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// Middleware returns a Middleware that waits for the limiter before every
+// attempt and throttles space according to the rate-limit headers of each
+// response.
+func (l *RateLimiter) Middleware(space string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := l.Wait(req.Context(), space); err != nil {
+				return nil, err
+			}
+
+			res, err := next(req)
+			if err == nil {
+				l.observe(space, res)
+			}
+			return res, err
+		}
+	}
+}
+
+// observe adapts to the server's view of the quota: a 429 blocks the space
+// for its Retry-After (or until the reset time), and a response reporting
+// zero remaining requests blocks it until the reset time.
+func (l *RateLimiter) observe(space string, res *http.Response) {
+	now := l.now()
+	reset, hasReset := rateLimitReset(res.Header, now)
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			l.Throttle(space, now.Add(after))
+		} else if hasReset {
+			l.Throttle(space, reset)
+		} else {
+			l.Throttle(space, now.Add(time.Second))
+		}
+		return
+	}
+
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil && remaining <= 0 && hasReset {
+		l.Throttle(space, reset)
+	}
+}
+
+// reserve takes a token for space and returns how long the caller must
+// wait before using it.
+func (l *RateLimiter) reserve(space string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(space)
+
+	var delay time.Duration
+	if l.rate > 0 {
+		b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+		b.last = now
+		b.tokens--
+
+		if b.tokens < 0 {
+			delay = time.Duration(-b.tokens / l.rate * float64(time.Second))
+		}
+	}
+
+	if blocked := b.blockedUntil.Sub(now); blocked > delay {
+		delay = blocked
+	}
+
+	return delay
+}
+
+// cancel returns a token taken by reserve that will not be used.
+func (l *RateLimiter) cancel(space string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bucket(space).tokens++
+}
+
+func (l *RateLimiter) bucket(space string) *bucket {
+	b, ok := l.buckets[space]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: l.now()}
+		l.buckets[space] = b
+	}
+	return b
+}
+
+// rateLimitReset reads X-RateLimit-Reset, given either as a unix timestamp
+// or as seconds from now.
+func rateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	v, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || v < 0 {
+		return time.Time{}, false
+	}
+
+	if v > now.Unix()-86400 {
+		return time.Unix(v, 0), true
+	}
+	return now.Add(time.Duration(v) * time.Second), true
+}
+
+// WithRateLimiter makes the client wait on limiter before each request to
+// its space.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.Use(limiter.Middleware(c.space))
+	}
+}
@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// frozen returns a limiter whose clock only moves when the returned
+// function is called.
+func frozen(rate float64, burst int) (*RateLimiter, func(time.Duration)) {
+	now := time.Now().Truncate(time.Second)
+
+	l := NewRateLimiter(rate, burst)
+	l.now = func() time.Time { return now }
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimiterBucket(t *testing.T) {
+	l, advance := frozen(10, 3)
+
+	for i := range 3 {
+		if d := l.reserve("spc-1"); d != 0 {
+			t.Fatalf("burst request %d waits %s, want 0", i+1, d)
+		}
+	}
+
+	if d := l.reserve("spc-1"); d != 100*time.Millisecond {
+		t.Fatalf("request past burst waits %s, want 100ms", d)
+	}
+	if d := l.reserve("spc-1"); d != 200*time.Millisecond {
+		t.Fatalf("second request past burst waits %s, want 200ms", d)
+	}
+
+	if d := l.reserve("spc-2"); d != 0 {
+		t.Fatalf("other space waits %s, want 0", d)
+	}
+
+	// refilling is capped at burst however long the space was idle
+	advance(time.Hour)
+	for i := range 3 {
+		if d := l.reserve("spc-1"); d != 0 {
+			t.Fatalf("request %d after idle waits %s, want 0", i+1, d)
+		}
+	}
+	if d := l.reserve("spc-1"); d == 0 {
+		t.Fatal("request past refilled burst does not wait")
+	}
+}
+
+func TestRateLimiterBurstMinimum(t *testing.T) {
+	l, _ := frozen(1, 0)
+
+	if d := l.reserve("spc-1"); d != 0 {
+		t.Fatalf("first request waits %s, want 0", d)
+	}
+	if d := l.reserve("spc-1"); d != time.Second {
+		t.Fatalf("second request waits %s, want 1s", d)
+	}
+}
+
+func TestRateLimiterWaitFailsFast(t *testing.T) {
+	l, _ := frozen(0.1, 1)
+	l.reserve("spc-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := l.Wait(ctx, "spc-1"); !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("Wait() = %v, want %v", err, common.ErrRateLimited)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("Wait() failed after %s, want immediately", elapsed)
+	}
+
+	// the failed Wait must not have used up a token
+	if d := l.reserve("spc-1"); d != 10*time.Second {
+		t.Fatalf("next request waits %s, want 10s", d)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.Wait(context.Background(), "spc-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	err := l.Wait(ctx, "spc-1")
+	if !errors.Is(err, common.ErrRateLimited) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() = %v, want %v and %v", err, common.ErrRateLimited, context.Canceled)
+	}
+}
+
+func TestRateLimiterWaitBlocks(t *testing.T) {
+	l := NewRateLimiter(20, 1)
+
+	start := time.Now()
+	for range 3 {
+		if err := l.Wait(context.Background(), "spc-1"); err != nil {
+			t.Fatalf("Wait() = %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("3 requests at 20/s with burst 1 took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterThrottle(t *testing.T) {
+	l, advance := frozen(0, 1)
+
+	if d := l.reserve("spc-1"); d != 0 {
+		t.Fatalf("request without a rate waits %s, want 0", d)
+	}
+
+	l.Throttle("spc-1", l.now().Add(5*time.Second))
+	l.Throttle("spc-1", l.now().Add(time.Second))
+
+	if d := l.reserve("spc-1"); d != 5*time.Second {
+		t.Fatalf("throttled request waits %s, want 5s", d)
+	}
+	if d := l.reserve("spc-2"); d != 0 {
+		t.Fatalf("other space waits %s, want 0", d)
+	}
+
+	advance(5 * time.Second)
+	if d := l.reserve("spc-1"); d != 0 {
+		t.Fatalf("request after throttle waits %s, want 0", d)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	l, _ := frozen(0, 1)
+	reset := l.now().Add(30 * time.Second).Unix()
+
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    time.Duration
+	}{
+		{"429 with Retry-After", 429, map[string]string{"Retry-After": "2"}, 2 * time.Second},
+		{"429 with reset timestamp", 429, map[string]string{"X-RateLimit-Reset": strconv.FormatInt(reset, 10)}, 30 * time.Second},
+		{"429 with reset seconds", 429, map[string]string{"X-RateLimit-Reset": "7"}, 7 * time.Second},
+		{"429 without hints", 429, nil, time.Second},
+		{"quota exhausted", 200, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "4"}, 4 * time.Second},
+		{"quota left", 200, map[string]string{"X-RateLimit-Remaining": "3", "X-RateLimit-Reset": "4"}, 0},
+		{"quota exhausted without reset", 200, map[string]string{"X-RateLimit-Remaining": "0"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for k, v := range tt.headers {
+				res.Header.Set(k, v)
+			}
+
+			l.observe(tt.name, res)
+
+			if d := l.reserve(tt.name); d != tt.want {
+				t.Fatalf("next request waits %s, want %s", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	rec := &recorder{responses: []response{{status: 429, retryAfter: "5"}, {status: 200}}}
+	c := newTestClient(t, rec, WithRetryPolicy(NoRetry()), WithRateLimiter(NewRateLimiter(0, 1)))
+
+	if _, err := Do[map[string]any](context.Background(), c, http.MethodGet, "/v1/payments", nil, nil); !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("first Do() = %v, want %v", err, common.ErrRateLimited)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := Do[map[string]any](ctx, c, http.MethodGet, "/v1/payments", nil, nil); !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("second Do() = %v, want %v", err, common.ErrRateLimited)
+	}
+	if got := rec.attempts(); got != 1 {
+		t.Fatalf("%d requests reached the server, want 1", got)
+	}
+}
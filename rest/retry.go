@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how Client retries transient failures. Retried
+// requests are resent unchanged, including their Idempotency-Key, so a POST
+// that reached Monime before failing is not applied twice.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values of 1 or less disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles on each
+	// subsequent attempt up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Jitter randomizes each delay by up to this fraction (0 to 1) of itself.
+	Jitter float64
+	// RetryableStatus lists the response codes worth retrying.
+	RetryableStatus []int
+	// RetryNetworkErrors retries requests that failed before a response was
+	// received, e.g. connection resets and timeouts.
+	RetryNetworkErrors bool
+}
+
+// DefaultRetryPolicy retries 429 and 502-504 responses and network errors up
+// to three attempts in total.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// NoRetry disables retries.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// SetRetryPolicy replaces the client's retry policy.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(retry-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// send executes req, retrying according to the client's policy. The caller
+// owns the returned response body.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := c.roundTrip(req)
+
+		retry, wait := c.shouldRetry(ctx, attempt, res, err)
+		if !retry {
+			return res, err
+		}
+
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		c.log.Warn("retrying HTTP request",
+			zap.String("trace_id", traceId),
+			zap.String("method", req.Method),
+			zap.String("path", req.URL.Path),
+			zap.Int("attempt", attempt),
+			zap.Duration("wait", wait),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) shouldRetry(ctx context.Context, attempt int, res *http.Response, err error) (bool, time.Duration) {
+	p := c.retry
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false, 0
+	}
+
+	switch {
+	case err != nil:
+		if !p.RetryNetworkErrors || errors.Is(err, common.ErrRateLimited) {
+			return false, 0
+		}
+	case !slices.Contains(p.RetryableStatus, res.StatusCode):
+		return false, 0
+	}
+
+	wait := p.backoff(attempt)
+	if res != nil {
+		if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			wait = after
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return false, 0
+	}
+
+	return true, wait
+}
+
+// retryAfter parses a Retry-After header given either as seconds or as an
+// HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,289 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/internal/nop"
+)
+
+// recorder answers each attempt with the next of its responses, repeating
+// the last one, and keeps the requests it received.
+type recorder struct {
+	mu        sync.Mutex
+	responses []response
+	requests  []recorded
+}
+
+type response struct {
+	status     int
+	retryAfter string
+}
+
+type recorded struct {
+	method string
+	path   string
+	query  string
+	header http.Header
+	body   string
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, recorded{
+		method: req.Method,
+		path:   req.URL.Path,
+		query:  req.URL.RawQuery,
+		header: req.Header.Clone(),
+		body:   string(body),
+	})
+
+	res := r.responses[min(len(r.requests), len(r.responses))-1]
+	if res.retryAfter != "" {
+		w.Header().Set("Retry-After", res.retryAfter)
+	}
+	w.WriteHeader(res.status)
+	w.Write([]byte(`{}`))
+}
+
+func (r *recorder) attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func fastRetries() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 10 * time.Millisecond
+	return p
+}
+
+func newTestClient(t *testing.T, rec *recorder, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, "token", "spc-1", "caph.2025-06-20", 5, nop.Logger{}, nop.Tracer{}, opts...)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		responses []response
+		attempts  int
+		want      error
+	}{
+		{"success", fastRetries(), []response{{status: 200}}, 1, nil},
+		{"recovers after 503", fastRetries(), []response{{status: 503}, {status: 200}}, 2, nil},
+		{"recovers after 429", fastRetries(), []response{{status: 429}, {status: 502}, {status: 200}}, 3, nil},
+		{"gives up after max attempts", fastRetries(), []response{{status: 503}}, 3, common.ErrServerError},
+		{"client error not retried", fastRetries(), []response{{status: 400}}, 1, common.ErrBadRequest},
+		{"500 not retried by default", fastRetries(), []response{{status: 500}}, 1, common.ErrServerError},
+		{"no retry", NoRetry(), []response{{status: 503}, {status: 200}}, 1, common.ErrServerError},
+		{"custom statuses", RetryPolicy{MaxAttempts: 2, RetryableStatus: []int{409}}, []response{{status: 409}, {status: 200}}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{responses: tt.responses}
+			c := newTestClient(t, rec, WithRetryPolicy(tt.policy))
+
+			_, err := Do[map[string]any](context.Background(), c, http.MethodGet, "/v1/payments", nil, nil)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.want)
+			}
+			if got := rec.attempts(); got != tt.attempts {
+				t.Fatalf("%d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryResendsBodyAndIdempotencyKey(t *testing.T) {
+	rec := &recorder{responses: []response{{status: 503}, {status: 503}, {status: 200}}}
+	c := newTestClient(t, rec, WithRetryPolicy(fastRetries()))
+
+	headers := &RequestOptions{Headers: map[string]string{"Idempotency-Key": "key-1"}}
+	if _, err := Do[map[string]any](context.Background(), c, http.MethodPost, "/v1/payouts", map[string]string{"name": "payout"}, headers); err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+
+	if len(rec.requests) != 3 {
+		t.Fatalf("%d attempts, want 3", len(rec.requests))
+	}
+	for i, req := range rec.requests {
+		if got := req.header.Get("Idempotency-Key"); got != "key-1" {
+			t.Errorf("attempt %d Idempotency-Key = %q, want key-1", i+1, got)
+		}
+		if req.body != `{"name":"payout"}` {
+			t.Errorf("attempt %d body = %q", i+1, req.body)
+		}
+	}
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		retry    bool
+		attempts int
+		wantErr  bool
+	}{
+		{"retried", true, 1, false},
+		{"not retried", false, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := fastRetries()
+			p.RetryNetworkErrors = tt.retry
+
+			// middlewares run once per attempt, so the count lives outside
+			var calls int
+			failFirst := func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					if calls++; calls == 1 {
+						return nil, errors.New("connection reset by peer")
+					}
+					return next(req)
+				}
+			}
+
+			rec := &recorder{responses: []response{{status: 200}}}
+			c := newTestClient(t, rec, WithRetryPolicy(p), WithMiddleware(failFirst))
+
+			_, err := Do[map[string]any](context.Background(), c, http.MethodGet, "/v1/payments", nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, want error %t", err, tt.wantErr)
+			}
+			if got := rec.attempts(); got != tt.attempts {
+				t.Fatalf("%d attempts reached the server, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	rec := &recorder{responses: []response{{status: 429, retryAfter: "1"}, {status: 200}}}
+	c := newTestClient(t, rec, WithRetryPolicy(fastRetries()))
+
+	start := time.Now()
+	if _, err := Do[map[string]any](context.Background(), c, http.MethodGet, "/v1/payments", nil, nil); err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+}
+
+func TestRetryStopsBeforeDeadline(t *testing.T) {
+	rec := &recorder{responses: []response{{status: 503, retryAfter: "5"}, {status: 200}}}
+	c := newTestClient(t, rec, WithRetryPolicy(fastRetries()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Do[map[string]any](ctx, c, http.MethodGet, "/v1/payments", nil, nil)
+	if !errors.Is(err, common.ErrServerError) {
+		t.Fatalf("Do() error = %v, want %v", err, common.ErrServerError)
+	}
+	if got := rec.attempts(); got != 1 {
+		t.Fatalf("%d attempts, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("gave up after %s, want immediately", elapsed)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	rec := &recorder{responses: []response{{status: 503}}}
+
+	p := fastRetries()
+	p.BaseDelay, p.MaxDelay, p.MaxAttempts = time.Hour, time.Hour, 10
+	c := newTestClient(t, rec, WithRetryPolicy(p))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := Do[map[string]any](ctx, c, http.MethodGet, "/v1/payments", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do() error = %v, want %v", err, context.Canceled)
+	}
+	if got := rec.attempts(); got != 1 {
+		t.Fatalf("%d attempts, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for retry, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		8: 300 * time.Millisecond,
+	} {
+		if got := p.backoff(retry); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", retry, got, want)
+		}
+	}
+
+	p.Jitter = 0.2
+	for range 100 {
+		if got := p.backoff(1); got < 80*time.Millisecond || got > 120*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %s, want within 20%% of 100ms", got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{"empty", "", 0, 0, false},
+		{"seconds", "3", 3 * time.Second, 3 * time.Second, true},
+		{"zero", "0", 0, 0, true},
+		{"negative", "-1", 0, 0, false},
+		{"garbage", "soon", 0, 0, false},
+		{"http date", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 8 * time.Second, 10 * time.Second, true},
+		{"http date in the past", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("retryAfter(%q) ok = %t, want %t", tt.value, ok, tt.ok)
+			}
+			if got < tt.min || got > tt.max {
+				t.Fatalf("retryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyStatuses(t *testing.T) {
+	want := []int{429, 502, 503, 504}
+	if got := DefaultRetryPolicy().RetryableStatus; !slices.Equal(got, want) {
+		t.Fatalf("RetryableStatus = %v, want %v", got, want)
+	}
+}
@@ -2,20 +2,22 @@ package checkout
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
 )
 
 // CreateCommand represents the command to create a financial account.
 type CreateCommand struct {
-	Name               string     `json:"name"`
-	Description        string     `json:"description"`
-	CancelURL          string     `json:"cancelUrl"`
-	SuccessURL         string     `json:"successUrl"`
-	CallbackState      string     `json:"callbackState"`
-	Reference          string     `json:"reference"`
-	FinancialAccountID string     `json:"financialAccountId"`
+	IdempotencyKey     string `json:"-"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	CancelURL          string `json:"cancelUrl"`
+	SuccessURL         string `json:"successUrl"`
+	CallbackState      string `json:"callbackState"`
+	Reference          string `json:"reference"`
+	FinancialAccountID string `json:"financialAccountId"`
 	LineItems          []Item `json:"lineItems"`
 }
 
@@ -26,43 +28,44 @@ func (c CreateCommand) CommandName() string {
 
 // Validate implements cqrs.Command.
 func (c CreateCommand) Validate() error {
-	var fields []string
+	var errs common.ValidationError
 
 	if c.Name == "" {
-		fields = append(fields, "name is required")
+		errs.Add("name", "is required")
 	}
 
 	if c.Reference == "" {
-		fields = append(fields, "reference is required")
+		errs.Add("reference", "is required")
 	}
 
 	if c.FinancialAccountID == "" {
-		fields = append(fields, "financialAccountId is required")
+		errs.Add("financialAccountId", "is required")
 	}
 
 	if c.SuccessURL == "" {
-		fields = append(fields, "successUrl is required")
+		errs.Add("successUrl", "is required")
 	}
 
 	if c.CancelURL == "" {
-		fields = append(fields, "cancelUrl is required")
+		errs.Add("cancelUrl", "is required")
 	}
 
 	if len(c.LineItems) == 0 {
-		fields = append(fields, "at least one line item is required")
+		errs.Add("lineItems", "must contain at least one item")
 	} else {
 		for i, item := range c.LineItems {
-			if err := item.Validate(); err != nil {
-				fields = append(fields, fmt.Sprintf("lineItems[%d]: %v", i, err))
-			}
+			errs.Nest(fmt.Sprintf("lineItems[%d]", i), item.Validate())
 		}
 	}
 
-	if len(fields) > 0 {
-		return fmt.Errorf("%s", strings.Join(fields, ", "))
-	}
+	return errs.Err()
+}
 
-	return nil
+// Total returns what the session will charge, summing every line item after
+// discounts. It fails with ErrUnpricedItem when a product item is present,
+// as only Monime knows its price.
+func (c CreateCommand) Total() (money.Amount, error) {
+	return Total(c.LineItems)
 }
 
 var _ cqrs.Command = CreateCommand{}
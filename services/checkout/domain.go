@@ -2,14 +2,31 @@ package checkout
 
 import (
 	"context"
+	"iter"
 
 	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Status is the lifecycle state of a checkout session.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusExpired   Status = "expired"
 )
 
 type LineItems struct {
 	Data []Item `json:"data"`
 }
 
+// Total sums the session's line items after discounts.
+func (l LineItems) Total() (money.Amount, error) {
+	return Total(l.Data)
+}
+
 type PaymentOptions struct {
 	Card   map[string]interface{} `json:"card"`
 	Bank   map[string]interface{} `json:"bank"`
@@ -25,6 +42,7 @@ type Domain struct {
 	ID                 string                 `json:"id"`
 	Name               string                 `json:"name"`
 	Description        string                 `json:"description"`
+	Status             Status                 `json:"status"`
 	CancelURL          string                 `json:"cancelUrl"`
 	SuccessURL         string                 `json:"successUrl"`
 	CallbackState      string                 `json:"callbackState"`
@@ -46,5 +64,7 @@ type Service interface {
 	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
 	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
 	Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error)
-	List(ctx context.Context) (*common.Response[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
 }
@@ -1,25 +1,66 @@
 package checkout
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
 )
 
-// Item represents a line item for checkout.
-type ItemPrice struct {
-	Currency string  `json:"currency"`
-	Value    float64 `json:"value"`
-}
+// ItemPrice is the unit price of a line item, in minor units.
+type ItemPrice = money.Amount
+
+// ItemType selects which fields of an Item are meaningful.
+type ItemType string
+
+const (
+	// ItemCustom is an ad hoc item described entirely by the session: it
+	// carries its own name and price.
+	ItemCustom ItemType = "custom"
+	// ItemProduct references a product set up in the Monime dashboard,
+	// which supplies the name and price.
+	ItemProduct ItemType = "product"
+)
+
+// ErrUnpricedItem is returned when totalling an item without a price, such
+// as a product item in a CreateCommand: Monime fills product prices in when
+// the session is created.
+var ErrUnpricedItem = errors.New("checkout: line item has no price")
 
+// Item represents a line item for checkout. Use CustomItem or ProductItem to
+// build one; only the fields belonging to Type are sent.
 type Item struct {
-	Type        string   `json:"type"`
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Quantity    int      `json:"quantity"`
-	Reference   string   `json:"reference"`
-	Price ItemPrice `json:"price"`
+	Type ItemType `json:"type"`
+	// ID is the product ID of a product item.
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Images      []string  `json:"images,omitempty"`
+	Quantity    int       `json:"quantity"`
+	Reference   string    `json:"reference,omitempty"`
+	Price       ItemPrice `json:"price,omitzero"`
+	Discount    *Discount `json:"discount,omitempty"`
+}
+
+// Discount lowers the total of a line item, either by a fixed Amount or by
+// a Percentage of price × quantity. Exactly one of the two is set.
+type Discount struct {
+	Amount     *money.Amount `json:"amount,omitempty"`
+	Percentage float64       `json:"percentage,omitempty"`
+}
+
+func CustomItem(name string, price ItemPrice, quantity int) Item {
+	return Item{Type: ItemCustom, Name: name, Price: price, Quantity: quantity}
+}
+
+func ProductItem(productID string, quantity int) Item {
+	return Item{Type: ItemProduct, ID: productID, Quantity: quantity}
 }
 
 // CommandName implements cqrs.Command.
@@ -29,41 +70,155 @@ func (i Item) CommandName() string {
 
 // Validate implements cqrs.Command.
 func (i Item) Validate() error {
-	var fields []string
-
-	if i.Type == "" {
-		fields = append(fields, "type is required")
+	var errs common.ValidationError
+
+	switch i.Type {
+	case ItemCustom:
+		if i.Name == "" {
+			errs.Add("name", "is required")
+		}
+
+		if i.Price.Currency == "" {
+			errs.Add("price.currency", "is required")
+		}
+
+		if i.Price.Value <= 0 {
+			errs.Add("price.value", "must be greater than 0")
+		}
+	case ItemProduct:
+		if i.ID == "" {
+			errs.Add("id", "is required")
+		}
+
+		if !i.Price.IsZero() || i.Price.Currency != "" {
+			errs.Add("price", "is set by the product and must be empty")
+		}
+	case "":
+		errs.Add("type", "is required")
+	default:
+		errs.Add("type", fmt.Sprintf("%q is not supported", i.Type))
 	}
 
-	if i.ID == "" {
-		fields = append(fields, "id is required")
+	if i.Quantity <= 0 {
+		errs.Add("quantity", "must be greater than 0")
 	}
 
-	if i.Name == "" {
-		fields = append(fields, "name is required")
+	for n, image := range i.Images {
+		if u, err := url.Parse(image); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errs.Add(fmt.Sprintf("images[%d]", n), "must be an absolute http(s) URL")
+		}
 	}
 
-	if i.Quantity <= 0 {
-		fields = append(fields, "quantity must be greater than 0")
+	if i.Discount != nil {
+		errs.Nest("discount", i.Discount.validate(i))
 	}
 
-	if i.Reference == "" {
-		fields = append(fields, "reference is required")
+	return errs.Err()
+}
+
+func (d Discount) validate(item Item) error {
+	var errs common.ValidationError
+
+	switch {
+	case d.Amount == nil && d.Percentage == 0:
+		errs.Add("", "must set amount or percentage")
+	case d.Amount != nil && d.Percentage != 0:
+		errs.Add("", "must set only one of amount and percentage")
+	case d.Amount != nil:
+		if d.Amount.Value <= 0 {
+			errs.Add("amount.value", "must be greater than 0")
+		}
+
+		if item.Type == ItemCustom {
+			if !strings.EqualFold(d.Amount.Currency, item.Price.Currency) {
+				errs.Add("amount.currency", "must match price.currency")
+			} else if subtotal, err := item.Subtotal(); err == nil && d.Amount.Value > subtotal.Value {
+				errs.Add("amount.value", "must not exceed price × quantity")
+			}
+		}
+	default:
+		if d.Percentage < 0 || d.Percentage > 100 {
+			errs.Add("percentage", "must be between 0 and 100")
+		}
 	}
 
+	return errs.Err()
+}
+
+// Subtotal returns price × quantity before any discount.
+func (i Item) Subtotal() (money.Amount, error) {
 	if i.Price.Currency == "" {
-		fields = append(fields, "price.currency is required")
+		return money.Amount{}, ErrUnpricedItem
+	}
+
+	return i.Price.Multiply(int64(i.Quantity))
+}
+
+// Total returns what the customer pays for the item: its Subtotal less the
+// discount. Percentage discounts are rounded to the nearest minor unit,
+// halves away from zero.
+func (i Item) Total() (money.Amount, error) {
+	subtotal, err := i.Subtotal()
+	if err != nil || i.Discount == nil {
+		return subtotal, err
 	}
 
-	if i.Price.Value <= 0 {
-		fields = append(fields, "price.value must be greater than 0")
+	if i.Discount.Amount != nil {
+		return subtotal.Sub(*i.Discount.Amount)
 	}
 
-	if len(fields) > 0 {
-		return fmt.Errorf("%s", strings.Join(fields, ", "))
+	off, err := percentOf(subtotal.Value, i.Discount.Percentage)
+	if err != nil {
+		return money.Amount{}, err
+	}
+
+	return subtotal.Sub(money.New(subtotal.Currency, off))
+}
+
+// percentOf returns pct percent of value in exact arithmetic. pct is read
+// as the shortest decimal that round-trips, so 0.1 means one tenth rather
+// than the binary fraction nearest to it.
+func percentOf(value int64, pct float64) (int64, error) {
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(pct, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("%w: percentage %v", money.ErrInvalidAmount, pct)
+	}
+
+	num := new(big.Int).Mul(big.NewInt(value), rate.Num())
+	den := new(big.Int).Mul(rate.Denom(), big.NewInt(100))
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if twice := new(big.Int).Lsh(r.Abs(r), 1); twice.Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+
+	if !q.IsInt64() {
+		return 0, money.ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+// Total sums the totals of items. All items must be priced and share a
+// currency.
+func Total(items []Item) (money.Amount, error) {
+	var sum money.Amount
+	for n, item := range items {
+		total, err := item.Total()
+		if err != nil {
+			return money.Amount{}, fmt.Errorf("lineItems[%d]: %w", n, err)
+		}
+
+		if n == 0 {
+			sum = total
+			continue
+		}
+
+		if sum, err = sum.Add(total); err != nil {
+			return money.Amount{}, fmt.Errorf("lineItems[%d]: %w", n, err)
+		}
 	}
 
-	return nil
+	return sum, nil
 }
 
 var _ cqrs.Command = Item{}
@@ -0,0 +1,196 @@
+package checkout
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+func sle(v int64) *money.Amount {
+	a := money.New("SLE", v)
+	return &a
+}
+
+func withDiscount(item Item, d Discount) Item {
+	item.Discount = &d
+	return item
+}
+
+func TestItemValidate(t *testing.T) {
+	custom := CustomItem("T-shirt", money.New("SLE", 1000), 2)
+	product := ProductItem("prod-1", 1)
+
+	tests := []struct {
+		name   string
+		item   Item
+		fields []string
+	}{
+		{"custom", custom, nil},
+		{"product", product, nil},
+		{"custom without name or price", Item{Type: ItemCustom, Quantity: 1}, []string{"name", "price.currency", "price.value"}},
+		{"custom with negative price", CustomItem("T-shirt", money.New("SLE", -1), 1), []string{"price.value"}},
+		{"product without id", ProductItem("", 1), []string{"id"}},
+		{"product with price", Item{Type: ItemProduct, ID: "prod-1", Quantity: 1, Price: money.New("SLE", 1000)}, []string{"price"}},
+		{"no type", Item{Name: "T-shirt", Quantity: 1}, []string{"type"}},
+		{"unknown type", Item{Type: "bundle", Quantity: 1}, []string{"type"}},
+		{"zero quantity", CustomItem("T-shirt", money.New("SLE", 1000), 0), []string{"quantity"}},
+		{"bad images", Item{Type: ItemProduct, ID: "prod-1", Quantity: 1, Images: []string{"https://cdn.example/a.png", "/b.png", "ftp://cdn.example/c.png"}}, []string{"images[1]", "images[2]"}},
+		{"amount discount", withDiscount(custom, Discount{Amount: sle(500)}), nil},
+		{"discount of the whole subtotal", withDiscount(custom, Discount{Amount: sle(2000)}), nil},
+		{"100% discount", withDiscount(custom, Discount{Percentage: 100}), nil},
+		{"empty discount", withDiscount(custom, Discount{}), []string{"discount"}},
+		{"both discounts", withDiscount(custom, Discount{Amount: sle(500), Percentage: 10}), []string{"discount"}},
+		{"zero amount discount", withDiscount(custom, Discount{Amount: sle(0)}), []string{"discount.amount.value"}},
+		{"amount above subtotal", withDiscount(custom, Discount{Amount: sle(2001)}), []string{"discount.amount.value"}},
+		{"discount currency mismatch", withDiscount(custom, Discount{Amount: &money.Amount{Currency: "USD", Value: 5}}), []string{"discount.amount.currency"}},
+		{"discount currency case ignored", withDiscount(custom, Discount{Amount: &money.Amount{Currency: "sle", Value: 5}}), nil},
+		{"negative percentage", withDiscount(custom, Discount{Percentage: -5}), []string{"discount.percentage"}},
+		{"percentage above 100", withDiscount(custom, Discount{Percentage: 100.5}), []string{"discount.percentage"}},
+		{"amount discount on product", withDiscount(product, Discount{Amount: sle(500)}), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.item.Validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var invalid *common.ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Validate() = %v, want *common.ValidationError", err)
+			}
+
+			var got []string
+			for _, f := range invalid.Fields {
+				got = append(got, f.Field)
+			}
+			if !slices.Equal(got, tt.fields) {
+				t.Fatalf("Validate() failed fields %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestItemJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    Item
+		present []string
+		absent  []string
+	}{
+		{"custom", CustomItem("T-shirt", money.New("SLE", 1000), 2), []string{`"type":"custom"`, `"name":"T-shirt"`, `"price":{"currency":"SLE","value":1000}`}, []string{`"id"`, `"discount"`}},
+		{"product", ProductItem("prod-1", 1), []string{`"type":"product"`, `"id":"prod-1"`, `"quantity":1`}, []string{`"price"`, `"name"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.item)
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+
+			for _, s := range tt.present {
+				if !strings.Contains(string(b), s) {
+					t.Errorf("%s lacks %s", b, s)
+				}
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(string(b), s) {
+					t.Errorf("%s contains %s", b, s)
+				}
+			}
+		})
+	}
+}
+
+func TestItemTotal(t *testing.T) {
+	item := func(price int64, quantity int) Item {
+		return CustomItem("T-shirt", money.New("SLE", price), quantity)
+	}
+
+	tests := []struct {
+		name     string
+		item     Item
+		subtotal int64
+		total    int64
+		wantErr  error
+	}{
+		{"no discount", item(1000, 3), 3000, 3000, nil},
+		{"amount", withDiscount(item(1000, 3), Discount{Amount: sle(250)}), 3000, 2750, nil},
+		{"whole subtotal", withDiscount(item(1000, 3), Discount{Amount: sle(3000)}), 3000, 0, nil},
+		{"100%", withDiscount(item(1000, 3), Discount{Percentage: 100}), 3000, 0, nil},
+		{"percentage rounds half up", withDiscount(item(999, 1), Discount{Percentage: 12.5}), 999, 874, nil},
+		{"percentage rounds down", withDiscount(item(999, 1), Discount{Percentage: 10}), 999, 899, nil},
+		{"decimal percentage is exact", withDiscount(item(1000, 1), Discount{Percentage: 0.1}), 1000, 999, nil},
+		{"beyond float precision", withDiscount(item(9007199254740993, 1), Discount{Percentage: 50}), 9007199254740993, 4503599627370496, nil},
+		{"discount currency mismatch", withDiscount(item(1000, 1), Discount{Amount: &money.Amount{Currency: "USD", Value: 5}}), 1000, 0, money.ErrCurrencyMismatch},
+		{"subtotal overflow", item(1<<62, 4), 0, 0, money.ErrOverflow},
+		{"unpriced product", ProductItem("prod-1", 1), 0, 0, ErrUnpricedItem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, err := tt.item.Total()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Total() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			subtotal, err := tt.item.Subtotal()
+			if err != nil || subtotal.Value != tt.subtotal {
+				t.Fatalf("Subtotal() = %v, %v, want %d", subtotal, err, tt.subtotal)
+			}
+			if total.Value != tt.total || total.Currency != "SLE" {
+				t.Fatalf("Total() = %v, want SLE %d", total, tt.total)
+			}
+		})
+	}
+}
+
+func TestTotal(t *testing.T) {
+	shirt := CustomItem("T-shirt", money.New("SLE", 1000), 2)
+	hat := withDiscount(CustomItem("Hat", money.New("SLE", 500), 1), Discount{Percentage: 20})
+
+	tests := []struct {
+		name    string
+		items   []Item
+		want    money.Amount
+		wantErr error
+		path    string
+	}{
+		{"none", nil, money.Amount{}, nil, ""},
+		{"one", []Item{shirt}, money.New("SLE", 2000), nil, ""},
+		{"several", []Item{shirt, hat}, money.New("SLE", 2400), nil, ""},
+		{"mixed currencies", []Item{shirt, CustomItem("Scarf", money.New("USD", 100), 1)}, money.Amount{}, money.ErrCurrencyMismatch, "lineItems[1]"},
+		{"unpriced item", []Item{shirt, ProductItem("prod-1", 1)}, money.Amount{}, ErrUnpricedItem, "lineItems[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Total(tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Total() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.HasPrefix(err.Error(), tt.path) {
+					t.Fatalf("Total() error = %q, want it prefixed with %s", err, tt.path)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("Total() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package checkout
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// ListParams filters the checkout sessions returned by Service.List. Zero
+// values are left out of the query.
+type ListParams struct {
+	common.ListOptions
+	Status             Status
+	Reference          string
+	FinancialAccountID string
+	CreatedAfter       time.Time
+	CreatedBefore      time.Time
+}
+
+// Query implements rest.Querier.
+func (p *ListParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	q := p.ListOptions.Query()
+
+	if p.Status != "" {
+		q.Set("status", string(p.Status))
+	}
+
+	if p.Reference != "" {
+		q.Set("reference", p.Reference)
+	}
+
+	if p.FinancialAccountID != "" {
+		q.Set("financialAccountId", p.FinancialAccountID)
+	}
+
+	if !p.CreatedAfter.IsZero() {
+		q.Set("createTimeAfter", p.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+
+	if !p.CreatedBefore.IsZero() {
+		q.Set("createTimeBefore", p.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	return q
+}
@@ -0,0 +1,42 @@
+package checkout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+func TestListParamsQuery(t *testing.T) {
+	wat := time.FixedZone("WAT", 60*60)
+
+	tests := []struct {
+		name   string
+		params *ListParams
+		want   string
+	}{
+		{"nil", nil, ""},
+		{"zero", &ListParams{}, ""},
+		{
+			name: "all",
+			params: &ListParams{
+				ListOptions:        common.ListOptions{Limit: 10, After: "cs-1"},
+				Status:             StatusPending,
+				Reference:          "order-1",
+				FinancialAccountID: "fa-1",
+				CreatedAfter:       time.Date(2025, 6, 1, 9, 30, 0, 0, wat),
+				CreatedBefore:      time.Date(2025, 6, 2, 0, 0, 0, 500, time.UTC),
+			},
+			want: "after=cs-1&createTimeAfter=2025-06-01T08%3A30%3A00Z&createTimeBefore=2025-06-02T00%3A00%3A00Z&financialAccountId=fa-1&limit=10&reference=order-1&status=pending",
+		},
+		{"only created before", &ListParams{CreatedBefore: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)}, "createTimeBefore=2025-06-02T00%3A00%3A00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.Query().Encode(); got != tt.want {
+				t.Fatalf("Query() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
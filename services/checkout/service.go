@@ -3,12 +3,11 @@ package checkout
 import (
 	"context"
 	"fmt"
-
-	"encoding/json"
+	"iter"
+	"net/http"
 
 	"github.com/ose-micro/core/logger"
 	"github.com/ose-micro/core/tracing"
-	"github.com/ose-micro/core/utils"
 	"github.com/ose-micro/monime/common"
 	"github.com/ose-micro/monime/rest"
 	"go.opentelemetry.io/otel/attribute"
@@ -25,21 +24,29 @@ type financialAccountService struct {
 
 // Create implements Service.
 func (f *financialAccountService) Create(ctx context.Context, command *CreateCommand) (*common.OneResponse[Domain], error) {
-	var data common.OneResponse[Domain]
-
 	ctx, span := f.tracer.Start(ctx, "app.financial_account.create.handler", trace.WithAttributes(
 		attribute.String("operation", "CREATE"),
 		attribute.String("payload", fmt.Sprintf("%+v", command))))
 
 	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
-	if _, err := f.client.POST(ctx, "/checkout-sessions", command, map[string]string{
-		"Idempotency-Key": utils.GenerateUUID(),
-	}, func(b []byte) (any, error) {
-		if err := json.Unmarshal(b, &data); err != nil {
-			return nil, err
-		}
-		return data, nil
-	}); err != nil {
+
+	if err := common.Validate(command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("invalid request to create checkout session",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", command)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, f.client, http.MethodPost, "/checkout-sessions", command, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, command.IdempotencyKey, command.Reference, "/checkout-sessions"),
+		},
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		f.log.Error("failed to create financial account",
@@ -51,29 +58,24 @@ func (f *financialAccountService) Create(ctx context.Context, command *CreateCom
 
 	f.log.Info("financial account created",
 		zap.String("trace_id", traceId),
-		zap.String("payload", fmt.Sprintf("%+v", data)),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
 	)
 
-	return &data, nil
+	return data, nil
 }
 
 // List implements Service.
-func (f *financialAccountService) List(ctx context.Context) (*common.Response[Domain], error) {
-	var data common.Response[Domain]
-
+func (f *financialAccountService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
 	ctx, span := f.tracer.Start(ctx, "app.financial_account.list.handler", trace.WithAttributes(
 		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
 	))
 	defer span.End()
 
 	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
 
-	if _, err := f.client.Get(ctx, "/checkout-sessions", nil, func(b []byte) (any, error) {
-		if err := json.Unmarshal(b, &data); err != nil {
-			return nil, err
-		}
-		return data, nil
-	}); err != nil {
+	data, err := rest.Do[common.Response[Domain]](ctx, f.client, http.MethodGet, "/checkout-sessions", params, nil)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		f.log.Error("failed to list financial accounts",
@@ -90,25 +92,49 @@ func (f *financialAccountService) List(ctx context.Context) (*common.Response[Do
 		zap.String("next", data.Pagination.Next),
 	)
 
-	return &data, nil
+	return data, nil
 }
 
-func (f *financialAccountService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
-	var data common.OneResponse[Domain]
+// Iter implements Service.
+func (f *financialAccountService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
 
-	ctx, span := f.tracer.Start(context.Background(), "app.financial_account.get.handler", trace.WithAttributes(
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (f *financialAccountService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+func (f *financialAccountService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := f.tracer.Start(ctx, "app.financial_account.get.handler", trace.WithAttributes(
 		attribute.String("operation", "GET"),
 	))
 	defer span.End()
 
 	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
 
-	if _, err := f.client.Get(ctx, fmt.Sprintf("/checkout-sessions/%s", id), nil, func(b []byte) (any, error) {
-		if err := json.Unmarshal(b, &data); err != nil {
-			return nil, err
-		}
-		return data, nil
-	}); err != nil {
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("invalid request to get checkout session",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, f.client, http.MethodGet, fmt.Sprintf("/checkout-sessions/%s", id), nil, nil)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		f.log.Error("failed to get financial account",
@@ -121,31 +147,38 @@ func (f *financialAccountService) Get(ctx context.Context, id string) (*common.O
 
 	f.log.Info("financial account fetched",
 		zap.String("trace_id", traceId),
-		zap.String("payload", fmt.Sprintf("%+v", data)),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
 	)
 
-	return &data, nil
+	return data, nil
 }
 
 func (f *financialAccountService) Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error) {
-	var data common.OneResponse[Domain]
-
-	ctx, span := f.tracer.Start(context.Background(), "app.financial_account.update.handler", trace.WithAttributes(
+	ctx, span := f.tracer.Start(ctx, "app.financial_account.update.handler", trace.WithAttributes(
 		attribute.String("operation", "UPDATE"),
 	))
 	defer span.End()
 
 	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
 
+	if err := common.Validate(cmd); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("invalid request to update checkout session",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", cmd)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
 	url := fmt.Sprintf("/checkout-sessions/%s", cmd.Id)
-	if _, err := f.client.PUT(ctx, url, cmd, map[string]string{
-		"Idempotency-Key": utils.GenerateUUID(),
-	}, func(b []byte) (any, error) {
-		if err := json.Unmarshal(b, &data); err != nil {
-			return nil, err
-		}
-		return data, nil
-	}); err != nil {
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, f.client, http.MethodPatch, url, cmd, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, cmd.IdempotencyKey, "", ""),
+		},
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		f.log.Error("failed to update financial account",
@@ -159,10 +192,10 @@ func (f *financialAccountService) Update(ctx context.Context, cmd *UpdateCommand
 	f.log.Info("financial account updated",
 		zap.String("trace_id", traceId),
 		zap.String("payload", fmt.Sprintf("%+v", cmd)),
-		zap.String("payload", fmt.Sprintf("%+v", data)),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
 	)
 
-	return &data, nil
+	return data, nil
 }
 
 func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
@@ -1,19 +1,18 @@
 package checkout
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
 )
 
-// UpdateCommand represents the command to create a financial account.
+// UpdateCommand is a partial update of a checkout session; empty fields are
+// left unchanged.
 type UpdateCommand struct {
-	Id        string            `json:"id"`
-	Name      string            `json:"name"`
-	Currency  string            `json:"currency"`
-	Reference string            `json:"reference"`
-	Metadata  map[string]string `json:"metadata"`
+	IdempotencyKey string            `json:"-"`
+	Id             string            `json:"id"`
+	Name           string            `json:"name,omitempty"`
+	Reference      string            `json:"reference,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
 }
 
 // CommandName implements cqrs.Command.
@@ -23,29 +22,13 @@ func (c UpdateCommand) CommandName() string {
 
 // Validate implements cqrs.Command.
 func (c UpdateCommand) Validate() error {
-	fields := make([]string, 0)
+	var errs common.ValidationError
 
 	if c.Id == "" {
-		fields = append(fields, "id is required")
-	}
-
-	if c.Currency == "" {
-		fields = append(fields, "currency is required")
-	}
-
-	if c.Name == "" {
-		fields = append(fields, "name is required")
-	}
-
-	if c.Reference == "" {
-		fields = append(fields, "reference is required")
-	}
-
-	if len(fields) > 0 {
-		return fmt.Errorf("%s", strings.Join(fields, ", "))
+		errs.Add("id", "is required")
 	}
 
-	return nil
+	return errs.Err()
 }
 
 var _ cqrs.Command = UpdateCommand{}
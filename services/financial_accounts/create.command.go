@@ -1,14 +1,13 @@
 package financial_accounts
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
 )
 
 // CreateCommand represents the command to create a financial account.
 type CreateCommand struct {
+	IdempotencyKey string            `json:"-"`
 	Name           string            `json:"name"`
 	Currency       string            `json:"currency"`
 	Reference      string            `json:"reference"`
@@ -22,25 +21,21 @@ func (c CreateCommand) CommandName() string {
 
 // Validate implements cqrs.Command.
 func (c CreateCommand) Validate() error {
-	fields := make([]string, 0)
+	var errs common.ValidationError
 
 	if c.Currency == "" {
-		fields = append(fields, "currency is required")
+		errs.Add("currency", "is required")
 	}
 
 	if c.Name == "" {
-		fields = append(fields, "name is required")
+		errs.Add("name", "is required")
 	}
 
 	if c.Reference == "" {
-		fields = append(fields, "reference is required")
-	}
-
-	if len(fields) > 0 {
-		return fmt.Errorf("%s", strings.Join(fields, ", "))
+		errs.Add("reference", "is required")
 	}
 
-	return nil
+	return errs.Err()
 }
 
 var _ cqrs.Command = CreateCommand{}
@@ -2,14 +2,13 @@ package financial_accounts
 
 import (
 	"context"
+	"iter"
 
 	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
 )
 
-type Available struct {
-	Currency string  `json:"currency"`
-	Value    float32 `json:"value"`
-}
+type Available = money.Amount
 
 type Balance struct {
 	Available Available `json:"available"`
@@ -34,5 +33,7 @@ type Service interface {
 	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
 	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
 	Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error)
-	List(ctx context.Context) (*common.Response[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
 }
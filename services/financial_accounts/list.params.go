@@ -0,0 +1,34 @@
+package financial_accounts
+
+import (
+	"net/url"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// ListParams filters the financial accounts returned by Service.List. Zero
+// values are left out of the query.
+type ListParams struct {
+	common.ListOptions
+	Reference string
+	Currency  string
+}
+
+// Query implements rest.Querier.
+func (p *ListParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	q := p.ListOptions.Query()
+
+	if p.Reference != "" {
+		q.Set("reference", p.Reference)
+	}
+
+	if p.Currency != "" {
+		q.Set("currency", p.Currency)
+	}
+
+	return q
+}
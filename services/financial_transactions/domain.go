@@ -0,0 +1,61 @@
+package financial_transactions
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Type is the direction of a ledger entry.
+type Type string
+
+const (
+	TypeCredit Type = "credit"
+	TypeDebit  Type = "debit"
+)
+
+type Amount = money.Amount
+
+type Balance struct {
+	After Amount `json:"after"`
+}
+
+// FinancialAccount is the account a transaction was posted to, with its
+// balance immediately after the posting.
+type FinancialAccount struct {
+	ID      string  `json:"id"`
+	Balance Balance `json:"balance"`
+}
+
+// Owner identifies the object that originated a transaction, e.g. a payment,
+// payout or internal transfer, and transitively that object's own owner.
+type Owner struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Owner *Owner `json:"owner"`
+}
+
+type OwnershipGraph struct {
+	Owner *Owner `json:"owner"`
+}
+
+type Domain struct {
+	ID               string                 `json:"id"`
+	Type             Type                   `json:"type"`
+	Amount           Amount                 `json:"amount"`
+	Reference        string                 `json:"reference"`
+	FinancialAccount FinancialAccount       `json:"financialAccount"`
+	OwnershipGraph   *OwnershipGraph        `json:"ownershipGraph"`
+	Metadata         map[string]interface{} `json:"metadata"`
+	Timestamp        string                 `json:"timestamp"`
+	CreatedAt        string                 `json:"createTime"`
+}
+
+type Service interface {
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
+}
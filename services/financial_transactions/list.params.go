@@ -0,0 +1,50 @@
+package financial_transactions
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// ListParams filters the transactions returned by Service.List. Zero values
+// are left out of the query.
+type ListParams struct {
+	common.ListOptions
+	FinancialAccountID string
+	Type               Type
+	Reference          string
+	TimestampAfter     time.Time
+	TimestampBefore    time.Time
+}
+
+// Query implements rest.Querier.
+func (p *ListParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	q := p.ListOptions.Query()
+
+	if p.FinancialAccountID != "" {
+		q.Set("financialAccountId", p.FinancialAccountID)
+	}
+
+	if p.Type != "" {
+		q.Set("type", string(p.Type))
+	}
+
+	if p.Reference != "" {
+		q.Set("reference", p.Reference)
+	}
+
+	if !p.TimestampAfter.IsZero() {
+		q.Set("timestampAfter", p.TimestampAfter.UTC().Format(time.RFC3339))
+	}
+
+	if !p.TimestampBefore.IsZero() {
+		q.Set("timestampBefore", p.TimestampBefore.UTC().Format(time.RFC3339))
+	}
+
+	return q
+}
@@ -0,0 +1,42 @@
+package financial_transactions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/common"
+)
+
+func TestListParamsQuery(t *testing.T) {
+	wat := time.FixedZone("WAT", 60*60)
+
+	tests := []struct {
+		name   string
+		params *ListParams
+		want   string
+	}{
+		{"nil", nil, ""},
+		{"zero", &ListParams{}, ""},
+		{
+			name: "all",
+			params: &ListParams{
+				ListOptions:        common.ListOptions{Limit: 50},
+				FinancialAccountID: "fa-1",
+				Type:               TypeDebit,
+				Reference:          "ref-1",
+				TimestampAfter:     time.Date(2025, 6, 1, 9, 30, 0, 0, wat),
+				TimestampBefore:    time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+			},
+			want: "financialAccountId=fa-1&limit=50&reference=ref-1&timestampAfter=2025-06-01T08%3A30%3A00Z&timestampBefore=2025-06-02T00%3A00%3A00Z&type=debit",
+		},
+		{"only type", &ListParams{Type: TypeCredit}, "type=credit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.Query().Encode(); got != tt.want {
+				t.Fatalf("Query() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
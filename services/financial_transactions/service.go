@@ -0,0 +1,121 @@
+package financial_transactions
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type financialTransactionService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// List implements Service.
+func (f *financialTransactionService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
+	ctx, span := f.tracer.Start(ctx, "app.financial_transaction.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, f.client, http.MethodGet, "/financial-transactions", params, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("failed to list financial transactions",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	f.log.Info("financial transactions fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (f *financialTransactionService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return f.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (f *financialTransactionService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(f.Iter(ctx, params), max)
+}
+
+// Get implements Service.
+func (f *financialTransactionService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := f.tracer.Start(ctx, "app.financial_transaction.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("invalid request to get financial transaction",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, f.client, http.MethodGet, fmt.Sprintf("/financial-transactions/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		f.log.Error("failed to get financial transaction",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	f.log.Info("financial transaction fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &financialTransactionService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
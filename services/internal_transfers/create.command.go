@@ -0,0 +1,52 @@
+package internal_transfers
+
+import (
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// CreateCommand represents the command to move funds between two financial accounts.
+type CreateCommand struct {
+	IdempotencyKey string `json:"-"`
+	// Reference is not sent; common.IdempotencyKey derives the key from it.
+	Reference                   string              `json:"-"`
+	Amount                      Amount              `json:"amount"`
+	SourceFinancialAccount      FinancialAccountRef `json:"sourceFinancialAccount"`
+	DestinationFinancialAccount FinancialAccountRef `json:"destinationFinancialAccount"`
+	Description                 string              `json:"description,omitempty"`
+	Metadata                    map[string]string   `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c CreateCommand) CommandName() string {
+	return CREATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c CreateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Amount.Currency == "" {
+		errs.Add("amount.currency", "is required")
+	}
+
+	if c.Amount.Value <= 0 {
+		errs.Add("amount.value", "must be greater than 0")
+	}
+
+	if c.SourceFinancialAccount.ID == "" {
+		errs.Add("sourceFinancialAccount.id", "is required")
+	}
+
+	if c.DestinationFinancialAccount.ID == "" {
+		errs.Add("destinationFinancialAccount.id", "is required")
+	}
+
+	if c.SourceFinancialAccount.ID != "" && c.SourceFinancialAccount.ID == c.DestinationFinancialAccount.ID {
+		errs.Add("destinationFinancialAccount.id", "must differ from sourceFinancialAccount.id")
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = CreateCommand{}
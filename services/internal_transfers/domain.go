@@ -0,0 +1,58 @@
+package internal_transfers
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Status is the lifecycle state of an internal transfer.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+type Amount = money.Amount
+
+// FinancialAccountRef points at one side of a transfer.
+type FinancialAccountRef struct {
+	ID string `json:"id"`
+}
+
+// FailureDetail explains why a transfer ended in StatusFailed.
+type FailureDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Domain struct {
+	ID                            string                 `json:"id"`
+	Status                        Status                 `json:"status"`
+	Amount                        Amount                 `json:"amount"`
+	SourceFinancialAccount        FinancialAccountRef    `json:"sourceFinancialAccount"`
+	DestinationFinancialAccount   FinancialAccountRef    `json:"destinationFinancialAccount"`
+	FinancialTransactionReference string                 `json:"financialTransactionReference"`
+	Description                   string                 `json:"description"`
+	FailureDetail                 *FailureDetail         `json:"failureDetail"`
+	Metadata                      map[string]interface{} `json:"metadata"`
+	CreatedAt                     string                 `json:"createTime"`
+	UpdatedAt                     string                 `json:"updateTime"`
+}
+
+const (
+	CREATED_COMMAND string = "internal_transfers.create.command"
+)
+
+type Service interface {
+	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
+}
@@ -0,0 +1,39 @@
+package internal_transfers
+
+import (
+	"net/url"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// ListParams filters the transfers returned by Service.List. Zero values are
+// left out of the query.
+type ListParams struct {
+	common.ListOptions
+	Status                        Status
+	SourceFinancialAccountID      string
+	DestinationFinancialAccountID string
+}
+
+// Query implements rest.Querier.
+func (p *ListParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	q := p.ListOptions.Query()
+
+	if p.Status != "" {
+		q.Set("status", string(p.Status))
+	}
+
+	if p.SourceFinancialAccountID != "" {
+		q.Set("sourceFinancialAccountId", p.SourceFinancialAccountID)
+	}
+
+	if p.DestinationFinancialAccountID != "" {
+		q.Set("destinationFinancialAccountId", p.DestinationFinancialAccountID)
+	}
+
+	return q
+}
@@ -0,0 +1,164 @@
+package internal_transfers
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type internalTransferService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// Create implements Service.
+func (t *internalTransferService) Create(ctx context.Context, command *CreateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := t.tracer.Start(ctx, "app.internal_transfer.create.handler", trace.WithAttributes(
+		attribute.String("operation", "CREATE"),
+		attribute.String("payload", fmt.Sprintf("%+v", command))))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.log.Error("invalid request to create internal transfer",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", command)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, t.client, http.MethodPost, "/internal-transfers", command, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, command.IdempotencyKey, command.Reference, "/internal-transfers"),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.log.Error("failed to create internal transfer",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	t.log.Info("internal transfer created",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// List implements Service.
+func (t *internalTransferService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
+	ctx, span := t.tracer.Start(ctx, "app.internal_transfer.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, t.client, http.MethodGet, "/internal-transfers", params, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.log.Error("failed to list internal transfers",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	t.log.Info("internal transfers fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (t *internalTransferService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return t.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (t *internalTransferService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(t.Iter(ctx, params), max)
+}
+
+// Get implements Service.
+func (t *internalTransferService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := t.tracer.Start(ctx, "app.internal_transfer.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.log.Error("invalid request to get internal transfer",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, t.client, http.MethodGet, fmt.Sprintf("/internal-transfers/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.log.Error("failed to get internal transfer",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	t.log.Info("internal transfer fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &internalTransferService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
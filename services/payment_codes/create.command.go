@@ -0,0 +1,63 @@
+package payment_codes
+
+import (
+	"fmt"
+
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// CreateCommand represents the command to create a payment code.
+type CreateCommand struct {
+	IdempotencyKey         string                  `json:"-"`
+	Name                   string                  `json:"name"`
+	Mode                   Mode                    `json:"mode"`
+	Enable                 bool                    `json:"enable"`
+	Amount                 Amount                  `json:"amount"`
+	Duration               string                  `json:"duration,omitempty"`
+	Customer               *Customer               `json:"customer,omitempty"`
+	Reference              string                  `json:"reference"`
+	AuthorizedProviders    []string                `json:"authorizedProviders,omitempty"`
+	AuthorizedPhoneNumber  string                  `json:"authorizedPhoneNumber,omitempty"`
+	RecurrentPaymentTarget *RecurrentPaymentTarget `json:"recurrentPaymentTarget,omitempty"`
+	FinancialAccountID     string                  `json:"financialAccountId,omitempty"`
+	Metadata               map[string]string       `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c CreateCommand) CommandName() string {
+	return CREATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c CreateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Name == "" {
+		errs.Add("name", "is required")
+	}
+
+	switch c.Mode {
+	case ModeOneTime, ModeRecurrent:
+	case "":
+		errs.Add("mode", "is required")
+	default:
+		errs.Add("mode", fmt.Sprintf("%q is not supported", c.Mode))
+	}
+
+	if c.Reference == "" {
+		errs.Add("reference", "is required")
+	}
+
+	if c.Amount.Currency == "" {
+		errs.Add("amount.currency", "is required")
+	}
+
+	if c.Amount.Value <= 0 {
+		errs.Add("amount.value", "must be greater than 0")
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = CreateCommand{}
@@ -0,0 +1,75 @@
+package payment_codes
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Mode determines whether a payment code can be paid once or repeatedly.
+type Mode string
+
+const (
+	ModeOneTime   Mode = "one_time"
+	ModeRecurrent Mode = "recurrent"
+)
+
+// Status is the lifecycle state of a payment code.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusExpired    Status = "expired"
+	StatusCompleted  Status = "completed"
+	StatusCancelled  Status = "cancelled"
+)
+
+type Amount = money.Amount
+
+type Customer struct {
+	Name string `json:"name"`
+}
+
+// RecurrentPaymentTarget bounds how often a recurrent payment code can be paid.
+type RecurrentPaymentTarget struct {
+	ExpectedPaymentCount int     `json:"expectedPaymentCount,omitempty"`
+	ExpectedPaymentTotal *Amount `json:"expectedPaymentTotal,omitempty"`
+}
+
+type Domain struct {
+	ID                     string                  `json:"id"`
+	Mode                   Mode                    `json:"mode"`
+	Status                 Status                  `json:"status"`
+	Name                   string                  `json:"name"`
+	Amount                 Amount                  `json:"amount"`
+	Enable                 bool                    `json:"enable"`
+	ExpireTime             string                  `json:"expireTime"`
+	Customer               *Customer               `json:"customer"`
+	UssdCode               string                  `json:"ussdCode"`
+	Reference              string                  `json:"reference"`
+	AuthorizedProviders    []string                `json:"authorizedProviders"`
+	AuthorizedPhoneNumber  string                  `json:"authorizedPhoneNumber"`
+	RecurrentPaymentTarget *RecurrentPaymentTarget `json:"recurrentPaymentTarget"`
+	FinancialAccountID     string                  `json:"financialAccountId"`
+	Metadata               map[string]interface{}  `json:"metadata"`
+	CreatedAt              string                  `json:"createTime"`
+	UpdatedAt              string                  `json:"updateTime"`
+}
+
+const (
+	CREATED_COMMAND string = "payment_codes.create.command"
+	UPDATED_COMMAND string = "payment_codes.update.command"
+)
+
+type Service interface {
+	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
+	Delete(ctx context.Context, id string) error
+}
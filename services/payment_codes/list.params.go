@@ -0,0 +1,49 @@
+package payment_codes
+
+import (
+	"net/url"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// ListParams filters the payment codes returned by Service.List. Zero values
+// are left out of the query.
+type ListParams struct {
+	common.ListOptions
+	Status             Status
+	Mode               Mode
+	Reference          string
+	UssdCode           string
+	FinancialAccountID string
+}
+
+// Query implements rest.Querier.
+func (p *ListParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	q := p.ListOptions.Query()
+
+	if p.Status != "" {
+		q.Set("status", string(p.Status))
+	}
+
+	if p.Mode != "" {
+		q.Set("mode", string(p.Mode))
+	}
+
+	if p.Reference != "" {
+		q.Set("reference", p.Reference)
+	}
+
+	if p.UssdCode != "" {
+		q.Set("ussdCode", p.UssdCode)
+	}
+
+	if p.FinancialAccountID != "" {
+		q.Set("financialAccountId", p.FinancialAccountID)
+	}
+
+	return q
+}
@@ -0,0 +1,248 @@
+package payment_codes
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type paymentCodeService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// Create implements Service.
+func (p *paymentCodeService) Create(ctx context.Context, command *CreateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment_code.create.handler", trace.WithAttributes(
+		attribute.String("operation", "CREATE"),
+		attribute.String("payload", fmt.Sprintf("%+v", command))))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to create payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", command)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, p.client, http.MethodPost, "/payment-codes", command, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, command.IdempotencyKey, command.Reference, "/payment-codes"),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to create payment code",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment code created",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// List implements Service.
+func (p *paymentCodeService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment_code.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, p.client, http.MethodGet, "/payment-codes", params, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to list payment codes",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment codes fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (p *paymentCodeService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return p.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (p *paymentCodeService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(p.Iter(ctx, params), max)
+}
+
+// Get implements Service.
+func (p *paymentCodeService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment_code.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to get payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, p.client, http.MethodGet, fmt.Sprintf("/payment-codes/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to get payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment code fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// Update implements Service.
+func (p *paymentCodeService) Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment_code.update.handler", trace.WithAttributes(
+		attribute.String("operation", "UPDATE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(cmd); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to update payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", cmd)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/payment-codes/%s", cmd.Id)
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, p.client, http.MethodPatch, url, cmd, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, cmd.IdempotencyKey, "", ""),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to update payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", cmd)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment code updated",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// Delete implements Service.
+func (p *paymentCodeService) Delete(ctx context.Context, id string) error {
+	ctx, span := p.tracer.Start(ctx, "app.payment_code.delete.handler", trace.WithAttributes(
+		attribute.String("operation", "DELETE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to delete payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if _, err := rest.Do[struct{}](ctx, p.client, http.MethodDelete, fmt.Sprintf("/payment-codes/%s", id), nil, nil); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to delete payment code",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	p.log.Info("payment code deleted",
+		zap.String("trace_id", traceId),
+		zap.String("payload", id),
+	)
+
+	return nil
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &paymentCodeService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
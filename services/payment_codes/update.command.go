@@ -0,0 +1,48 @@
+package payment_codes
+
+import (
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// UpdateCommand represents the command to update a payment code.
+type UpdateCommand struct {
+	IdempotencyKey        string            `json:"-"`
+	Id                    string            `json:"id"`
+	Name                  string            `json:"name,omitempty"`
+	Enable                *bool             `json:"enable,omitempty"`
+	Amount                *Amount           `json:"amount,omitempty"`
+	Duration              string            `json:"duration,omitempty"`
+	Customer              *Customer         `json:"customer,omitempty"`
+	AuthorizedPhoneNumber string            `json:"authorizedPhoneNumber,omitempty"`
+	FinancialAccountID    string            `json:"financialAccountId,omitempty"`
+	Metadata              map[string]string `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c UpdateCommand) CommandName() string {
+	return UPDATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c UpdateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Id == "" {
+		errs.Add("id", "is required")
+	}
+
+	if c.Amount != nil {
+		if c.Amount.Currency == "" {
+			errs.Add("amount.currency", "is required")
+		}
+
+		if c.Amount.Value <= 0 {
+			errs.Add("amount.value", "must be greater than 0")
+		}
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = UpdateCommand{}
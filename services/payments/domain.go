@@ -0,0 +1,124 @@
+package payments
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Status is the lifecycle state of a payment.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+// ChannelType is the rail a payment was collected through.
+type ChannelType string
+
+const (
+	ChannelCard   ChannelType = "card"
+	ChannelMomo   ChannelType = "momo"
+	ChannelBank   ChannelType = "bank"
+	ChannelWallet ChannelType = "wallet"
+)
+
+// Owner types that can originate a payment.
+const (
+	OwnerCheckoutSession string = "checkout_session"
+	OwnerPaymentCode     string = "payment_code"
+)
+
+type Amount = money.Amount
+
+type Channel struct {
+	Type      ChannelType `json:"type"`
+	Provider  string      `json:"provider"`
+	AccountID string      `json:"accountId"`
+}
+
+type Payer struct {
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phoneNumber"`
+	Email       string `json:"email"`
+}
+
+type Fee struct {
+	Code   string `json:"code"`
+	Amount Amount `json:"amount"`
+}
+
+// Owner identifies the object a payment was produced by, and transitively
+// that object's own owner.
+type Owner struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Owner *Owner `json:"owner"`
+}
+
+type OwnershipGraph struct {
+	Owner *Owner `json:"owner"`
+}
+
+type Domain struct {
+	ID                            string                 `json:"id"`
+	Status                        Status                 `json:"status"`
+	Name                          string                 `json:"name"`
+	Amount                        Amount                 `json:"amount"`
+	Channel                       Channel                `json:"channel"`
+	Payer                         *Payer                 `json:"payer"`
+	Reference                     string                 `json:"reference"`
+	OrderNumber                   string                 `json:"orderNumber"`
+	FinancialAccountID            string                 `json:"financialAccountId"`
+	FinancialTransactionReference string                 `json:"financialTransactionReference"`
+	Fees                          []Fee                  `json:"fees"`
+	OwnershipGraph                *OwnershipGraph        `json:"ownershipGraph"`
+	Metadata                      map[string]interface{} `json:"metadata"`
+	CreatedAt                     string                 `json:"createTime"`
+	UpdatedAt                     string                 `json:"updateTime"`
+}
+
+// OriginID returns the ID of the nearest owner of the given type, such as
+// OwnerCheckoutSession, or an empty string if the payment has none.
+func (d Domain) OriginID(ownerType string) string {
+	if d.OwnershipGraph == nil {
+		return ""
+	}
+
+	for o := d.OwnershipGraph.Owner; o != nil; o = o.Owner {
+		if o.Type == ownerType {
+			return o.ID
+		}
+	}
+
+	return ""
+}
+
+// CheckoutSessionID returns the ID of the checkout session that produced the
+// payment, if any.
+func (d Domain) CheckoutSessionID() string {
+	return d.OriginID(OwnerCheckoutSession)
+}
+
+// PaymentCodeID returns the ID of the payment code that produced the payment,
+// if any.
+func (d Domain) PaymentCodeID() string {
+	return d.OriginID(OwnerPaymentCode)
+}
+
+const (
+	UPDATED_COMMAND string = "payments.update.command"
+)
+
+type Service interface {
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
+}
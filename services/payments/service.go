@@ -0,0 +1,166 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type paymentService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// List implements Service.
+func (p *paymentService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, p.client, http.MethodGet, "/payments", params, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to list payments",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payments fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (p *paymentService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return p.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (p *paymentService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(p.Iter(ctx, params), max)
+}
+
+// Get implements Service.
+func (p *paymentService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to get payment",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, p.client, http.MethodGet, fmt.Sprintf("/payments/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to get payment",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// Update implements Service.
+func (p *paymentService) Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := p.tracer.Start(ctx, "app.payment.update.handler", trace.WithAttributes(
+		attribute.String("operation", "UPDATE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(cmd); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("invalid request to update payment",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", cmd)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/payments/%s", cmd.Id)
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, p.client, http.MethodPatch, url, cmd, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, cmd.IdempotencyKey, "", ""),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		p.log.Error("failed to update payment",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", cmd)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	p.log.Info("payment updated",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &paymentService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
@@ -0,0 +1,32 @@
+package payments
+
+import (
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// UpdateCommand represents the command to patch a payment.
+type UpdateCommand struct {
+	IdempotencyKey string            `json:"-"`
+	Id             string            `json:"id"`
+	Name           string            `json:"name,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c UpdateCommand) CommandName() string {
+	return UPDATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c UpdateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Id == "" {
+		errs.Add("id", "is required")
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = UpdateCommand{}
@@ -0,0 +1,45 @@
+package payouts
+
+import (
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// CreateCommand represents the command to create a payout.
+type CreateCommand struct {
+	IdempotencyKey string `json:"-"`
+	// Reference is not sent; common.IdempotencyKey derives the key from it.
+	Reference   string            `json:"-"`
+	Amount      Amount            `json:"amount"`
+	Source      *Source           `json:"source,omitempty"`
+	Destination Destination       `json:"destination"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c CreateCommand) CommandName() string {
+	return CREATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c CreateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Amount.Currency == "" {
+		errs.Add("amount.currency", "is required")
+	}
+
+	if c.Amount.Value <= 0 {
+		errs.Add("amount.value", "must be greater than 0")
+	}
+
+	if c.Source != nil && c.Source.FinancialAccountID == "" {
+		errs.Add("source.financialAccountId", "is required")
+	}
+
+	errs.Nest("destination", c.Destination.Validate())
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = CreateCommand{}
@@ -0,0 +1,69 @@
+package payouts
+
+import (
+	"fmt"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// DestinationType selects which fields of a Destination are meaningful.
+type DestinationType string
+
+const (
+	DestinationMomo   DestinationType = "momo"
+	DestinationBank   DestinationType = "bank"
+	DestinationWallet DestinationType = "wallet"
+)
+
+// Destination is where a payout is delivered. Only the fields belonging to
+// Type are sent; use MomoDestination, BankDestination or WalletDestination
+// to build one.
+type Destination struct {
+	Type          DestinationType `json:"type"`
+	ProviderID    string          `json:"providerId"`
+	PhoneNumber   string          `json:"phoneNumber,omitempty"`
+	AccountNumber string          `json:"accountNumber,omitempty"`
+	WalletID      string          `json:"walletId,omitempty"`
+}
+
+func MomoDestination(providerID, phoneNumber string) Destination {
+	return Destination{Type: DestinationMomo, ProviderID: providerID, PhoneNumber: phoneNumber}
+}
+
+func BankDestination(providerID, accountNumber string) Destination {
+	return Destination{Type: DestinationBank, ProviderID: providerID, AccountNumber: accountNumber}
+}
+
+func WalletDestination(providerID, walletID string) Destination {
+	return Destination{Type: DestinationWallet, ProviderID: providerID, WalletID: walletID}
+}
+
+// Validate checks that the fields required by the destination type are set.
+func (d Destination) Validate() error {
+	var errs common.ValidationError
+
+	if d.ProviderID == "" {
+		errs.Add("providerId", "is required")
+	}
+
+	switch d.Type {
+	case DestinationMomo:
+		if d.PhoneNumber == "" {
+			errs.Add("phoneNumber", "is required")
+		}
+	case DestinationBank:
+		if d.AccountNumber == "" {
+			errs.Add("accountNumber", "is required")
+		}
+	case DestinationWallet:
+		if d.WalletID == "" {
+			errs.Add("walletId", "is required")
+		}
+	case "":
+		errs.Add("type", "is required")
+	default:
+		errs.Add("type", fmt.Sprintf("%q is not supported", d.Type))
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,62 @@
+package payouts
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/money"
+)
+
+// Status is the lifecycle state of a payout.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+type Amount = money.Amount
+
+type Source struct {
+	FinancialAccountID string `json:"financialAccountId"`
+}
+
+type Fee struct {
+	Code   string `json:"code"`
+	Amount Amount `json:"amount"`
+}
+
+// FailureDetail explains why a payout ended in StatusFailed.
+type FailureDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Domain struct {
+	ID            string                 `json:"id"`
+	Status        Status                 `json:"status"`
+	Amount        Amount                 `json:"amount"`
+	Source        Source                 `json:"source"`
+	Destination   Destination            `json:"destination"`
+	Fees          []Fee                  `json:"fees"`
+	FailureDetail *FailureDetail         `json:"failureDetail"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	CreatedAt     string                 `json:"createTime"`
+	UpdatedAt     string                 `json:"updateTime"`
+}
+
+const (
+	CREATED_COMMAND string = "payouts.create.command"
+)
+
+type Service interface {
+	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, params *ListParams) (*common.Response[Domain], error)
+	Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error)
+	Delete(ctx context.Context, id string) error
+}
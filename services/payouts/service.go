@@ -0,0 +1,203 @@
+package payouts
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type payoutService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// Create implements Service.
+func (o *payoutService) Create(ctx context.Context, command *CreateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := o.tracer.Start(ctx, "app.payout.create.handler", trace.WithAttributes(
+		attribute.String("operation", "CREATE"),
+		attribute.String("payload", fmt.Sprintf("%+v", command))))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("invalid request to create payout",
+			zap.String("trace_id", traceId),
+			zap.String("payload", fmt.Sprintf("%+v", command)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, o.client, http.MethodPost, "/payouts", command, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, command.IdempotencyKey, command.Reference, "/payouts"),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("failed to create payout",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	o.log.Info("payout created",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// List implements Service.
+func (o *payoutService) List(ctx context.Context, params *ListParams) (*common.Response[Domain], error) {
+	ctx, span := o.tracer.Start(ctx, "app.payout.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", params)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, o.client, http.MethodGet, "/payouts", params, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("failed to list payouts",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	o.log.Info("payouts fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (o *payoutService) Iter(ctx context.Context, params *ListParams) iter.Seq2[Domain, error] {
+	var base ListParams
+	if params != nil {
+		base = *params
+	}
+
+	return common.Paginate(ctx, &base.ListOptions, func(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+		page := base
+		page.ListOptions = *opts
+		return o.List(ctx, &page)
+	})
+}
+
+// ListAll implements Service.
+func (o *payoutService) ListAll(ctx context.Context, params *ListParams, max int) ([]Domain, error) {
+	return common.Collect(o.Iter(ctx, params), max)
+}
+
+// Get implements Service.
+func (o *payoutService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := o.tracer.Start(ctx, "app.payout.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("invalid request to get payout",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, o.client, http.MethodGet, fmt.Sprintf("/payouts/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("failed to get payout",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	o.log.Info("payout fetched",
+		zap.String("trace_id", traceId),
+		zap.String("payload", fmt.Sprintf("%+v", *data)),
+	)
+
+	return data, nil
+}
+
+// Delete implements Service.
+func (o *payoutService) Delete(ctx context.Context, id string) error {
+	ctx, span := o.tracer.Start(ctx, "app.payout.delete.handler", trace.WithAttributes(
+		attribute.String("operation", "DELETE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("invalid request to delete payout",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if _, err := rest.Do[struct{}](ctx, o.client, http.MethodDelete, fmt.Sprintf("/payouts/%s", id), nil, nil); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		o.log.Error("failed to delete payout",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	o.log.Info("payout deleted",
+		zap.String("trace_id", traceId),
+		zap.String("payload", id),
+	)
+
+	return nil
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &payoutService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
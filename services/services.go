@@ -6,16 +6,34 @@ import (
 	"github.com/ose-micro/monime/rest"
 	"github.com/ose-micro/monime/services/checkout"
 	"github.com/ose-micro/monime/services/financial_accounts"
+	"github.com/ose-micro/monime/services/financial_transactions"
+	"github.com/ose-micro/monime/services/internal_transfers"
+	"github.com/ose-micro/monime/services/payment_codes"
+	"github.com/ose-micro/monime/services/payments"
+	"github.com/ose-micro/monime/services/payouts"
+	"github.com/ose-micro/monime/services/webhooks"
 )
 
 type Service struct {
-	FinancialAccount financial_accounts.Service
-	Checkout         checkout.Service
+	FinancialAccount     financial_accounts.Service
+	Checkout             checkout.Service
+	PaymentCode          payment_codes.Service
+	Payout               payouts.Service
+	InternalTransfer     internal_transfers.Service
+	FinancialTransaction financial_transactions.Service
+	Payment              payments.Service
+	Webhook              webhooks.Service
 }
 
 func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) *Service {
 	return &Service{
-		FinancialAccount: financial_accounts.NewService(client, log, tracer),
-		Checkout:         checkout.NewService(client, log, tracer),
+		FinancialAccount:     financial_accounts.NewService(client, log, tracer),
+		Checkout:             checkout.NewService(client, log, tracer),
+		PaymentCode:          payment_codes.NewService(client, log, tracer),
+		Payout:               payouts.NewService(client, log, tracer),
+		InternalTransfer:     internal_transfers.NewService(client, log, tracer),
+		FinancialTransaction: financial_transactions.NewService(client, log, tracer),
+		Payment:              payments.NewService(client, log, tracer),
+		Webhook:              webhooks.NewService(client, log, tracer),
 	}
 }
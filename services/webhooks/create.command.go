@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// CreateCommand represents the command to create a webhook endpoint. A nil
+// Enabled creates an enabled endpoint.
+type CreateCommand struct {
+	IdempotencyKey string `json:"-"`
+	// Reference is not sent; common.IdempotencyKey derives the key from it.
+	Reference          string            `json:"-"`
+	Name               string            `json:"name"`
+	URL                string            `json:"url"`
+	Enabled            *bool             `json:"enabled,omitempty"`
+	Events             []string          `json:"events"`
+	APIRelease         string            `json:"apiRelease,omitempty"`
+	VerificationMethod *Verification     `json:"verificationMethod,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	AlertEmails        []string          `json:"alertEmails,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c CreateCommand) CommandName() string {
+	return CREATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c CreateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Name == "" {
+		errs.Add("name", "is required")
+	}
+
+	if c.URL == "" {
+		errs.Add("url", "is required")
+	} else if u, err := url.Parse(c.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs.Add("url", "must be an absolute https URL")
+	}
+
+	if len(c.Events) == 0 {
+		errs.Add("events", "must contain at least one event")
+	}
+
+	if c.VerificationMethod != nil {
+		errs.Nest("verificationMethod", validateVerification(c.VerificationMethod))
+	}
+
+	return errs.Err()
+}
+
+func validateVerification(v *Verification) error {
+	var errs common.ValidationError
+
+	switch v.Type {
+	case AlgorithmHmacSHA256:
+		if v.Secret == "" {
+			errs.Add("secret", "is required")
+		}
+	case AlgorithmES256:
+	case "":
+		errs.Add("type", "is required")
+	default:
+		errs.Add("type", fmt.Sprintf("%q is not supported", v.Type))
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = CreateCommand{}
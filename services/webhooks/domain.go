@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ose-micro/monime/common"
+)
+
+// Algorithm is the scheme Monime uses to sign deliveries to an endpoint.
+type Algorithm string
+
+const (
+	AlgorithmHmacSHA256 Algorithm = "HmacSHA256"
+	AlgorithmES256      Algorithm = "ES256"
+)
+
+// Verification describes how deliveries to the endpoint are signed. Secret is
+// only populated for HMAC endpoints; ES256 endpoints expose a PublicKey.
+type Verification struct {
+	Type      Algorithm `json:"type"`
+	Secret    string    `json:"secret,omitempty"`
+	PublicKey string    `json:"publicKey,omitempty"`
+}
+
+type Domain struct {
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	URL                string                 `json:"url"`
+	Enabled            bool                   `json:"enabled"`
+	Events             []string               `json:"events"`
+	APIRelease         string                 `json:"apiRelease"`
+	VerificationMethod Verification           `json:"verificationMethod"`
+	Headers            map[string]string      `json:"headers"`
+	AlertEmails        []string               `json:"alertEmails"`
+	Metadata           map[string]interface{} `json:"metadata"`
+	CreatedAt          string                 `json:"createTime"`
+	UpdatedAt          string                 `json:"updateTime"`
+}
+
+const (
+	CREATED_COMMAND string = "webhooks.create.command"
+	UPDATED_COMMAND string = "webhooks.update.command"
+)
+
+type Service interface {
+	Create(ctx context.Context, cmd *CreateCommand) (*common.OneResponse[Domain], error)
+	Get(ctx context.Context, id string) (*common.OneResponse[Domain], error)
+	Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error)
+	List(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error)
+	Iter(ctx context.Context, opts *common.ListOptions) iter.Seq2[Domain, error]
+	ListAll(ctx context.Context, opts *common.ListOptions, max int) ([]Domain, error)
+	Delete(ctx context.Context, id string) error
+}
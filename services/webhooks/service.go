@@ -0,0 +1,255 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/common"
+	"github.com/ose-micro/monime/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type webhookService struct {
+	client *rest.Client
+	log    logger.Logger
+	tracer tracing.Tracer
+}
+
+// Create implements Service.
+func (w *webhookService) Create(ctx context.Context, command *CreateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := w.tracer.Start(ctx, "app.webhook.create.handler", trace.WithAttributes(
+		attribute.String("operation", "CREATE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("invalid request to create webhook",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// send a copy so the caller's command keeps a nil Enabled
+	body := *command
+	if body.Enabled == nil {
+		enabled := true
+		body.Enabled = &enabled
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, w.client, http.MethodPost, "/webhooks", &body, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, command.IdempotencyKey, command.Reference, "/webhooks"),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("failed to create webhook",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	w.log.Info("webhook created",
+		zap.String("trace_id", traceId),
+		zap.String("id", data.Result.ID),
+	)
+
+	return data, nil
+}
+
+// List implements Service.
+func (w *webhookService) List(ctx context.Context, opts *common.ListOptions) (*common.Response[Domain], error) {
+	ctx, span := w.tracer.Start(ctx, "app.webhook.list.handler", trace.WithAttributes(
+		attribute.String("operation", "LIST"),
+		attribute.String("payload", fmt.Sprintf("%+v", opts)),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	data, err := rest.Do[common.Response[Domain]](ctx, w.client, http.MethodGet, "/webhooks", opts, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("failed to list webhooks",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	w.log.Info("webhooks fetched",
+		zap.String("trace_id", traceId),
+		zap.Strings("ids", ids(data.Result)),
+		zap.Int("count", data.Pagination.Count),
+		zap.String("next", data.Pagination.Next),
+	)
+
+	return data, nil
+}
+
+// Iter implements Service.
+func (w *webhookService) Iter(ctx context.Context, opts *common.ListOptions) iter.Seq2[Domain, error] {
+	return common.Paginate(ctx, opts, w.List)
+}
+
+// ListAll implements Service.
+func (w *webhookService) ListAll(ctx context.Context, opts *common.ListOptions, max int) ([]Domain, error) {
+	return common.Collect(w.Iter(ctx, opts), max)
+}
+
+// Get implements Service.
+func (w *webhookService) Get(ctx context.Context, id string) (*common.OneResponse[Domain], error) {
+	ctx, span := w.tracer.Start(ctx, "app.webhook.get.handler", trace.WithAttributes(
+		attribute.String("operation", "GET"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("invalid request to get webhook",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, w.client, http.MethodGet, fmt.Sprintf("/webhooks/%s", id), nil, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("failed to get webhook",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	w.log.Info("webhook fetched",
+		zap.String("trace_id", traceId),
+		zap.String("id", data.Result.ID),
+	)
+
+	return data, nil
+}
+
+// Update implements Service.
+func (w *webhookService) Update(ctx context.Context, cmd *UpdateCommand) (*common.OneResponse[Domain], error) {
+	ctx, span := w.tracer.Start(ctx, "app.webhook.update.handler", trace.WithAttributes(
+		attribute.String("operation", "UPDATE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.Validate(cmd); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("invalid request to update webhook",
+			zap.String("trace_id", traceId),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/webhooks/%s", cmd.Id)
+	data, err := rest.Do[common.OneResponse[Domain]](ctx, w.client, http.MethodPatch, url, cmd, &rest.RequestOptions{
+		Headers: map[string]string{
+			"Idempotency-Key": common.IdempotencyKey(ctx, cmd.IdempotencyKey, "", ""),
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("failed to update webhook",
+			zap.String("trace_id", traceId),
+			zap.String("id", cmd.Id),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	w.log.Info("webhook updated",
+		zap.String("trace_id", traceId),
+		zap.String("id", data.Result.ID),
+	)
+
+	return data, nil
+}
+
+// Delete implements Service.
+func (w *webhookService) Delete(ctx context.Context, id string) error {
+	ctx, span := w.tracer.Start(ctx, "app.webhook.delete.handler", trace.WithAttributes(
+		attribute.String("operation", "DELETE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if err := common.ValidateID(id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("invalid request to delete webhook",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if _, err := rest.Do[struct{}](ctx, w.client, http.MethodDelete, fmt.Sprintf("/webhooks/%s", id), nil, nil); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.log.Error("failed to delete webhook",
+			zap.String("trace_id", traceId),
+			zap.String("payload", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	w.log.Info("webhook deleted",
+		zap.String("trace_id", traceId),
+		zap.String("payload", id),
+	)
+
+	return nil
+}
+
+// ids lists the IDs of webhooks for logging. Whole webhooks are never
+// logged, since they carry the signing secret and custom headers, which
+// often hold credentials.
+func ids(webhooks []Domain) []string {
+	out := make([]string, 0, len(webhooks))
+	for _, w := range webhooks {
+		out = append(out, w.ID)
+	}
+	return out
+}
+
+func NewService(client *rest.Client, log logger.Logger, tracer tracing.Tracer) Service {
+	return &webhookService{
+		client: client,
+		log:    log,
+		tracer: tracer,
+	}
+}
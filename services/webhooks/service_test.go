@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ose-micro/monime/internal/nop"
+	"github.com/ose-micro/monime/rest"
+)
+
+func TestCreateEnabled(t *testing.T) {
+	no := false
+
+	tests := []struct {
+		name    string
+		enabled *bool
+		want    bool
+	}{
+		{"unset", nil, true},
+		{"disabled", &no, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				json.Unmarshal(b, &sent)
+				w.Write([]byte(`{"success":true,"result":{"id":"wh-1"}}`))
+			}))
+			defer srv.Close()
+
+			client := rest.New(srv.URL, "token", "spc-1", "caph.2025-06-20", 5, nop.Logger{}, nop.Tracer{})
+			command := &CreateCommand{
+				Name:    "Orders",
+				URL:     "https://example.com/hooks",
+				Enabled: tt.enabled,
+				Events:  []string{"payment.completed"},
+			}
+
+			if _, err := NewService(client, nop.Logger{}, nop.Tracer{}).Create(context.Background(), command); err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+
+			if got, ok := sent["enabled"].(bool); !ok || got != tt.want {
+				t.Fatalf("sent enabled = %v, want %v", sent["enabled"], tt.want)
+			}
+			if command.Enabled != tt.enabled {
+				t.Fatal("Create() changed the caller's command")
+			}
+		})
+	}
+}
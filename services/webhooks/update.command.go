@@ -0,0 +1,50 @@
+package webhooks
+
+import (
+	"net/url"
+
+	"github.com/ose-micro/cqrs"
+	"github.com/ose-micro/monime/common"
+)
+
+// UpdateCommand represents the command to update a webhook endpoint.
+type UpdateCommand struct {
+	IdempotencyKey     string            `json:"-"`
+	Id                 string            `json:"id"`
+	Name               string            `json:"name,omitempty"`
+	URL                string            `json:"url,omitempty"`
+	Enabled            *bool             `json:"enabled,omitempty"`
+	Events             []string          `json:"events,omitempty"`
+	VerificationMethod *Verification     `json:"verificationMethod,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	AlertEmails        []string          `json:"alertEmails,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// CommandName implements cqrs.Command.
+func (c UpdateCommand) CommandName() string {
+	return UPDATED_COMMAND
+}
+
+// Validate implements cqrs.Command.
+func (c UpdateCommand) Validate() error {
+	var errs common.ValidationError
+
+	if c.Id == "" {
+		errs.Add("id", "is required")
+	}
+
+	if c.URL != "" {
+		if u, err := url.Parse(c.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			errs.Add("url", "must be an absolute https URL")
+		}
+	}
+
+	if c.VerificationMethod != nil {
+		errs.Nest("verificationMethod", validateVerification(c.VerificationMethod))
+	}
+
+	return errs.Err()
+}
+
+var _ cqrs.Command = UpdateCommand{}
@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileCompactMin is the number of lines appended since the last compaction
+// below which the file is never rewritten.
+const fileCompactMin = 1024
+
+const (
+	fileClaimed   = "claimed"
+	fileReleased  = "released"
+	fileProcessed = "processed"
+)
+
+type fileEntry struct {
+	at        time.Time
+	processed bool
+}
+
+// FileStore is a DedupStore backed by an append-only file of
+// "<event id>\t<unix nanos>\t<state>" lines, so claimed and processed IDs
+// survive restarts of a single instance. The file is compacted, dropping
+// expired IDs, when it is opened and whenever the lines appended since the
+// last compaction outnumber the live IDs.
+type FileStore struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	window   time.Duration
+	entries  map[string]fileEntry
+	appended int
+	now      func() time.Time
+}
+
+func OpenFileStore(path string, window time.Duration) (*FileStore, error) {
+	f := &FileStore{
+		path:    path,
+		window:  window,
+		entries: make(map[string]fileEntry),
+		now:     time.Now,
+	}
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	if err := f.compact(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Claim implements DedupStore.
+func (f *FileStore) Claim(_ context.Context, id string) (Claim, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if e, ok := f.entries[id]; ok {
+		switch {
+		case e.processed && now.Sub(e.at) <= f.window:
+			return ClaimProcessed, nil
+		case !e.processed && now.Sub(e.at) < DefaultClaimTimeout:
+			return ClaimInFlight, nil
+		}
+	}
+
+	if err := f.append(id, now, fileClaimed); err != nil {
+		return 0, err
+	}
+
+	f.entries[id] = fileEntry{at: now}
+	return ClaimAcquired, nil
+}
+
+// Release implements DedupStore.
+func (f *FileStore) Release(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if e, ok := f.entries[id]; !ok || e.processed {
+		return nil
+	}
+
+	if err := f.append(id, f.now(), fileReleased); err != nil {
+		return err
+	}
+
+	delete(f.entries, id)
+	return nil
+}
+
+// Mark implements DedupStore.
+func (f *FileStore) Mark(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	at := f.now()
+	if err := f.append(id, at, fileProcessed); err != nil {
+		return err
+	}
+
+	f.entries[id] = fileEntry{at: at, processed: true}
+	return nil
+}
+
+// Close closes the underlying file.
+func (f *FileStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
+
+// append writes one line, compacting the file first once enough lines
+// have piled up (or after a failed compaction left it closed).
+func (f *FileStore) append(id string, at time.Time, state string) error {
+	if f.file == nil || (f.appended >= fileCompactMin && f.appended >= len(f.entries)) {
+		if err := f.compact(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(f.file, "%s\t%d\t%s\n", id, at.UnixNano(), state); err != nil {
+		return fmt.Errorf("webhook: write dedup file: %w", err)
+	}
+
+	f.appended++
+	return nil
+}
+
+func (f *FileStore) load() error {
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("webhook: open dedup file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 2 {
+			continue
+		}
+
+		n, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// lines written before claims existed carry no state
+		state := fileProcessed
+		if len(fields) > 2 {
+			state = fields[2]
+		}
+
+		switch state {
+		case fileClaimed:
+			f.entries[fields[0]] = fileEntry{at: time.Unix(0, n)}
+		case fileProcessed:
+			f.entries[fields[0]] = fileEntry{at: time.Unix(0, n), processed: true}
+		case fileReleased:
+			delete(f.entries, fields[0])
+		}
+	}
+
+	return scanner.Err()
+}
+
+// compact drops expired IDs from memory and rewrites the file with the
+// rest, then reopens it for appending.
+func (f *FileStore) compact() error {
+	now := f.now()
+	for id, e := range f.entries {
+		if (e.processed && now.Sub(e.at) > f.window) || (!e.processed && now.Sub(e.at) >= DefaultClaimTimeout) {
+			delete(f.entries, id)
+		}
+	}
+
+	if f.file != nil {
+		err := f.file.Close()
+		f.file = nil
+		if err != nil {
+			return fmt.Errorf("webhook: compact dedup file: %w", err)
+		}
+	}
+
+	tmp := f.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("webhook: compact dedup file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	for id, e := range f.entries {
+		state := fileClaimed
+		if e.processed {
+			state = fileProcessed
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", id, e.at.UnixNano(), state)
+	}
+
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("webhook: compact dedup file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("webhook: compact dedup file: %w", err)
+	}
+
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("webhook: compact dedup file: %w", err)
+	}
+
+	file, err = os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("webhook: open dedup file: %w", err)
+	}
+
+	f.file = file
+	f.appended = 0
+
+	return nil
+}
+
+var _ DedupStore = (*FileStore)(nil)
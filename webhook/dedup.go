@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrEventInFlight is returned by Dedup when another delivery of the same
+// event is still being handled. It is answered with 503 so Monime retries
+// once that delivery has finished or failed.
+var ErrEventInFlight = errors.New("webhook: event is already being handled")
+
+// ErrMissingEventID is returned by Dedup for events without an ID, which
+// cannot be told apart from each other. It is answered with 400.
+var ErrMissingEventID = errors.New("webhook: event has no id")
+
+// DefaultClaimTimeout is how long a claim may stay in flight before it is
+// considered abandoned, e.g. by a process that crashed mid-handler, and the
+// event can be claimed again. Handlers should finish well within it.
+const DefaultClaimTimeout = 5 * time.Minute
+
+// Claim is the outcome of DedupStore.Claim.
+type Claim int
+
+const (
+	// ClaimAcquired means the caller now owns the event and must either
+	// Mark or Release it.
+	ClaimAcquired Claim = iota
+	// ClaimProcessed means the event was marked within the store's window.
+	ClaimProcessed
+	// ClaimInFlight means another caller holds a live claim on the event.
+	ClaimInFlight
+)
+
+// DedupStore remembers which events have been processed so redeliveries of
+// the same event ID can be dropped. Claim must be atomic: of any number of
+// concurrent callers claiming the same id, at most one gets ClaimAcquired.
+// Implementations decide how long a processed ID is remembered.
+type DedupStore interface {
+	// Claim records id as in flight unless it is already processed or held
+	// by a claim younger than DefaultClaimTimeout.
+	Claim(ctx context.Context, id string) (Claim, error)
+	// Release drops an in-flight claim so the event can be handled again.
+	Release(ctx context.Context, id string) error
+	// Mark records id as processed.
+	Mark(ctx context.Context, id string) error
+}
+
+// Dedup wraps next so each event is handled at most once at a time and
+// events already recorded in store are acknowledged without being handled
+// again. The event is claimed before next runs, marked once it succeeds and
+// released if it fails, so failed deliveries are still retried. Events
+// without an ID are rejected with ErrMissingEventID rather than sharing the
+// empty key, which would drop every ID-less event after the first. Concurrent
+// redeliveries are answered with 503, as is any failure of the store
+// itself, so Monime tries again later. If Release fails the claim lapses
+// after DefaultClaimTimeout.
+func Dedup(store DedupStore, next EventHandler) EventHandler {
+	return EventHandlerFunc(func(ctx context.Context, event *Event) error {
+		if event.ID() == "" {
+			return &StatusError{Status: http.StatusBadRequest, Err: ErrMissingEventID}
+		}
+
+		claim, err := store.Claim(ctx, event.ID())
+		if err != nil {
+			return &StatusError{Status: http.StatusServiceUnavailable, Err: err}
+		}
+
+		switch claim {
+		case ClaimProcessed:
+			return nil
+		case ClaimInFlight:
+			return Retry(ErrEventInFlight)
+		}
+
+		if err := next.HandleEvent(ctx, event); err != nil {
+			store.Release(context.WithoutCancel(ctx), event.ID())
+			return err
+		}
+
+		if err := store.Mark(context.WithoutCancel(ctx), event.ID()); err != nil {
+			return &StatusError{Status: http.StatusServiceUnavailable, Err: err}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	id        string
+	at        time.Time
+	processed bool
+}
+
+// MemoryStore is an in-process DedupStore that keeps at most capacity IDs,
+// evicting the least recently claimed or marked first. Processed IDs older
+// than window are treated as unseen.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	window   time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+func NewMemoryStore(capacity int, window time.Duration) *MemoryStore {
+	return &MemoryStore{
+		capacity: capacity,
+		window:   window,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Claim implements DedupStore.
+func (m *MemoryStore) Claim(_ context.Context, id string) (Claim, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if el, ok := m.entries[id]; ok {
+		e := el.Value.(*memoryEntry)
+		switch {
+		case e.processed && now.Sub(e.at) <= m.window:
+			return ClaimProcessed, nil
+		case !e.processed && now.Sub(e.at) < DefaultClaimTimeout:
+			return ClaimInFlight, nil
+		}
+
+		e.at, e.processed = now, false
+		m.order.MoveToFront(el)
+		return ClaimAcquired, nil
+	}
+
+	m.entries[id] = m.order.PushFront(&memoryEntry{id: id, at: now})
+	m.evict()
+
+	return ClaimAcquired, nil
+}
+
+// Release implements DedupStore.
+func (m *MemoryStore) Release(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[id]; ok && !el.Value.(*memoryEntry).processed {
+		m.order.Remove(el)
+		delete(m.entries, id)
+	}
+
+	return nil
+}
+
+// Mark implements DedupStore.
+func (m *MemoryStore) Mark(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[id]; ok {
+		e := el.Value.(*memoryEntry)
+		e.at, e.processed = m.now(), true
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.entries[id] = m.order.PushFront(&memoryEntry{id: id, at: m.now(), processed: true})
+	m.evict()
+
+	return nil
+}
+
+func (m *MemoryStore) evict() {
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).id)
+	}
+}
+
+var _ DedupStore = (*MemoryStore)(nil)
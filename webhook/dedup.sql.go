@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Placeholder selects the bind parameter syntax of the SQL driver.
+type Placeholder int
+
+const (
+	// PlaceholderQuestion binds with "?" (MySQL, SQLite).
+	PlaceholderQuestion Placeholder = iota
+	// PlaceholderDollar binds with "$1", "$2", ... (PostgreSQL).
+	PlaceholderDollar
+)
+
+// SQLStore is a DedupStore backed by a database table, so claimed and
+// processed IDs are shared between every instance receiving webhooks. A
+// claim is a plain INSERT, so the primary key decides which of several
+// concurrent deliveries handles the event. The table is created by Migrate:
+//
+//	CREATE TABLE <table> (event_id VARCHAR(255) PRIMARY KEY, claimed_at BIGINT NOT NULL, processed_at BIGINT)
+type SQLStore struct {
+	db          *sql.DB
+	table       string
+	window      time.Duration
+	placeholder Placeholder
+	now         func() time.Time
+}
+
+func NewSQLStore(db *sql.DB, table string, window time.Duration, placeholder Placeholder) *SQLStore {
+	return &SQLStore{
+		db:          db,
+		table:       table,
+		window:      window,
+		placeholder: placeholder,
+		now:         time.Now,
+	}
+}
+
+// Migrate creates the dedup table if it does not exist.
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (event_id VARCHAR(255) PRIMARY KEY, claimed_at BIGINT NOT NULL, processed_at BIGINT)",
+		s.table,
+	))
+	return err
+}
+
+// Claim implements DedupStore.
+func (s *SQLStore) Claim(ctx context.Context, id string) (Claim, error) {
+	now := s.now()
+
+	_, insertErr := s.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (event_id, claimed_at) VALUES (%s, %s)", s.table, s.bind(1), s.bind(2)),
+		id, now.UnixNano(),
+	)
+	if insertErr == nil {
+		return ClaimAcquired, nil
+	}
+
+	// The insert failed, most likely on the primary key. Look at the row to
+	// tell a duplicate from a database error.
+	var claimedAt int64
+	var processedAt sql.NullInt64
+
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT claimed_at, processed_at FROM %s WHERE event_id = %s", s.table, s.bind(1)),
+		id,
+	).Scan(&claimedAt, &processedAt)
+	if err == sql.ErrNoRows {
+		return 0, insertErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case processedAt.Valid && now.Sub(time.Unix(0, processedAt.Int64)) <= s.window:
+		return ClaimProcessed, nil
+	case !processedAt.Valid && now.Sub(time.Unix(0, claimedAt)) < DefaultClaimTimeout:
+		return ClaimInFlight, nil
+	}
+
+	// The row is expired or an abandoned claim. Take it over only if nobody
+	// else has since, by matching the claimed_at we read.
+	res, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET claimed_at = %s, processed_at = NULL WHERE event_id = %s AND claimed_at = %s",
+			s.table, s.bind(1), s.bind(2), s.bind(3)),
+		now.UnixNano(), id, claimedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return 0, err
+	} else if n == 0 {
+		return ClaimInFlight, nil
+	}
+
+	return ClaimAcquired, nil
+}
+
+// Release implements DedupStore.
+func (s *SQLStore) Release(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE event_id = %s AND processed_at IS NULL", s.table, s.bind(1)),
+		id,
+	)
+	return err
+}
+
+// Mark implements DedupStore.
+func (s *SQLStore) Mark(ctx context.Context, id string) error {
+	now := s.now().UnixNano()
+
+	res, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET processed_at = %s WHERE event_id = %s", s.table, s.bind(1), s.bind(2)),
+		now, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+
+	// The claim was pruned while the handler ran.
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (event_id, claimed_at, processed_at) VALUES (%s, %s, %s)",
+			s.table, s.bind(1), s.bind(2), s.bind(3)),
+		id, now, now,
+	)
+	return err
+}
+
+// Prune deletes processed IDs older than the window and abandoned claims.
+func (s *SQLStore) Prune(ctx context.Context) error {
+	now := s.now()
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE processed_at < %s OR (processed_at IS NULL AND claimed_at < %s)",
+			s.table, s.bind(1), s.bind(2)),
+		now.Add(-s.window).UnixNano(), now.Add(-DefaultClaimTimeout).UnixNano(),
+	)
+	return err
+}
+
+func (s *SQLStore) bind(n int) string {
+	if s.placeholder == PlaceholderDollar {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+var _ DedupStore = (*SQLStore)(nil)
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func dedupStores(t *testing.T) map[string]func() DedupStore {
+	return map[string]func() DedupStore{
+		"memory": func() DedupStore { return NewMemoryStore(100, time.Hour) },
+		"file": func() DedupStore {
+			f, err := OpenFileStore(filepath.Join(t.TempDir(), "dedup.log"), time.Hour)
+			if err != nil {
+				t.Fatalf("OpenFileStore() = %v", err)
+			}
+			t.Cleanup(func() { f.Close() })
+			return f
+		},
+	}
+}
+
+func TestDedupHandlesConcurrentRedeliveriesOnce(t *testing.T) {
+	for name, open := range dedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var runs atomic.Int32
+			release := make(chan struct{})
+
+			h := Dedup(open(), EventHandlerFunc(func(context.Context, *Event) error {
+				runs.Add(1)
+				<-release
+				return nil
+			}))
+
+			event := &Event{Meta: Meta{ID: "evt-1"}}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 10)
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- h.HandleEvent(context.Background(), event)
+				}()
+			}
+
+			// let every redelivery reach the store before the first finishes
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			close(errs)
+
+			var inFlight int
+			for err := range errs {
+				switch {
+				case errors.Is(err, ErrEventInFlight):
+					if statusFor(err) != 503 {
+						t.Errorf("status for in-flight redelivery = %d, want 503", statusFor(err))
+					}
+					inFlight++
+				case err != nil:
+					t.Errorf("HandleEvent() = %v", err)
+				}
+			}
+
+			if got := runs.Load(); got != 1 {
+				t.Fatalf("handler ran %d times, want 1", got)
+			}
+			if inFlight != 9 {
+				t.Fatalf("%d redeliveries answered in flight, want 9", inFlight)
+			}
+
+			if err := h.HandleEvent(context.Background(), event); err != nil {
+				t.Fatalf("HandleEvent() after processing = %v, want nil", err)
+			}
+			if got := runs.Load(); got != 1 {
+				t.Fatalf("handler ran %d times after redelivery, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDedupReleasesFailedEvents(t *testing.T) {
+	for name, open := range dedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var runs atomic.Int32
+			fail := errors.New("downstream unavailable")
+
+			h := Dedup(open(), EventHandlerFunc(func(context.Context, *Event) error {
+				if runs.Add(1) == 1 {
+					return fail
+				}
+				return nil
+			}))
+
+			event := &Event{Meta: Meta{ID: "evt-1"}}
+
+			if err := h.HandleEvent(context.Background(), event); !errors.Is(err, fail) {
+				t.Fatalf("first HandleEvent() = %v, want %v", err, fail)
+			}
+			if err := h.HandleEvent(context.Background(), event); err != nil {
+				t.Fatalf("second HandleEvent() = %v, want nil", err)
+			}
+			if got := runs.Load(); got != 2 {
+				t.Fatalf("handler ran %d times, want 2", got)
+			}
+		})
+	}
+}
+
+func TestStoresReclaimAbandonedClaims(t *testing.T) {
+	for name, open := range dedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			store := open()
+			now := time.Now()
+			clock := func() time.Time { return now }
+
+			switch s := store.(type) {
+			case *MemoryStore:
+				s.now = clock
+			case *FileStore:
+				s.now = clock
+			}
+
+			ctx := context.Background()
+			if got, _ := store.Claim(ctx, "evt-1"); got != ClaimAcquired {
+				t.Fatalf("Claim() = %v, want ClaimAcquired", got)
+			}
+			if got, _ := store.Claim(ctx, "evt-1"); got != ClaimInFlight {
+				t.Fatalf("Claim() while held = %v, want ClaimInFlight", got)
+			}
+
+			now = now.Add(DefaultClaimTimeout)
+			if got, _ := store.Claim(ctx, "evt-1"); got != ClaimAcquired {
+				t.Fatalf("Claim() after timeout = %v, want ClaimAcquired", got)
+			}
+
+			if err := store.Mark(ctx, "evt-1"); err != nil {
+				t.Fatalf("Mark() = %v", err)
+			}
+			if got, _ := store.Claim(ctx, "evt-1"); got != ClaimProcessed {
+				t.Fatalf("Claim() after Mark = %v, want ClaimProcessed", got)
+			}
+
+			now = now.Add(time.Hour + time.Second)
+			if got, _ := store.Claim(ctx, "evt-1"); got != ClaimAcquired {
+				t.Fatalf("Claim() after window = %v, want ClaimAcquired", got)
+			}
+		})
+	}
+}
+
+func TestFileStoreSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedup.log")
+	ctx := context.Background()
+
+	f, err := OpenFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileStore() = %v", err)
+	}
+	f.Claim(ctx, "processed")
+	f.Mark(ctx, "processed")
+	f.Claim(ctx, "released")
+	f.Release(ctx, "released")
+	f.Close()
+
+	f, err = OpenFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileStore() = %v", err)
+	}
+	defer f.Close()
+
+	if got, _ := f.Claim(ctx, "processed"); got != ClaimProcessed {
+		t.Fatalf("Claim(processed) = %v, want ClaimProcessed", got)
+	}
+	if got, _ := f.Claim(ctx, "released"); got != ClaimAcquired {
+		t.Fatalf("Claim(released) = %v, want ClaimAcquired", got)
+	}
+}
+
+func TestFileStoreCompactsWhileRunning(t *testing.T) {
+	f, err := OpenFileStore(filepath.Join(t.TempDir(), "dedup.log"), time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileStore() = %v", err)
+	}
+	defer f.Close()
+
+	ctx := context.Background()
+	for range 5 * fileCompactMin {
+		f.Claim(ctx, "evt-1")
+		f.Release(ctx, "evt-1")
+	}
+
+	if f.appended > fileCompactMin {
+		t.Fatalf("%d lines appended since the last compaction, want at most %d", f.appended, fileCompactMin)
+	}
+	if len(f.entries) != 0 {
+		t.Fatalf("%d live entries, want 0", len(f.entries))
+	}
+}
+
+func TestDedupRejectsEventsWithoutID(t *testing.T) {
+	for name, open := range dedupStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var runs atomic.Int32
+			h := Dedup(open(), EventHandlerFunc(func(context.Context, *Event) error {
+				runs.Add(1)
+				return nil
+			}))
+
+			for _, name := range []string{PayoutFailed, PaymentCompleted} {
+				err := h.HandleEvent(context.Background(), &Event{Meta: Meta{Name: name}})
+				if !errors.Is(err, ErrMissingEventID) {
+					t.Fatalf("HandleEvent(%s) = %v, want %v", name, err, ErrMissingEventID)
+				}
+				if got := statusFor(err); got != 400 {
+					t.Fatalf("status for %s = %d, want 400", name, got)
+				}
+			}
+
+			if got := runs.Load(); got != 0 {
+				t.Fatalf("handler ran %d times, want 0", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature header")
+	ErrMalformedHeader  = errors.New("webhook: malformed signature header")
+	ErrInvalidSignature = errors.New("webhook: signature mismatch")
+	ErrTimestampExpired = errors.New("webhook: timestamp outside tolerance")
+)
+
+// StatusError lets an EventHandler choose the HTTP status returned to
+// Monime. Any other error is answered with 500 so the event is redelivered.
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook: status %d: %v", e.Status, e.Err)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent marks err as caused by the event itself rather than by a
+// transient failure; the delivery is answered with 422 Unprocessable Entity.
+// Monime treats every non-2xx answer as failed, so the event is still
+// redelivered. Handlers that want a bad event dropped should log it and
+// return nil instead.
+func Permanent(err error) error {
+	return &StatusError{Status: http.StatusUnprocessableEntity, Err: err}
+}
+
+// Retry asks Monime to redeliver later by answering with 503 Service
+// Unavailable, e.g. when a downstream dependency is down.
+func Retry(err error) error {
+	return &StatusError{Status: http.StatusServiceUnavailable, Err: err}
+}
+
+func statusFor(err error) int {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Status
+	}
+	return http.StatusInternalServerError
+}
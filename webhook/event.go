@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ose-micro/monime/services/checkout"
+	"github.com/ose-micro/monime/services/internal_transfers"
+	"github.com/ose-micro/monime/services/payment_codes"
+	"github.com/ose-micro/monime/services/payments"
+	"github.com/ose-micro/monime/services/payouts"
+)
+
+// Event names delivered by Monime.
+const (
+	CheckoutSessionCompleted  string = "checkout_session.completed"
+	CheckoutSessionExpired    string = "checkout_session.expired"
+	CheckoutSessionCancelled  string = "checkout_session.cancelled"
+	PaymentCreated            string = "payment.created"
+	PaymentCompleted          string = "payment.completed"
+	PaymentFailed             string = "payment.failed"
+	PaymentCodeCreated        string = "payment_code.created"
+	PaymentCodeCompleted      string = "payment_code.completed"
+	PaymentCodeExpired        string = "payment_code.expired"
+	PayoutCompleted           string = "payout.completed"
+	PayoutFailed              string = "payout.failed"
+	InternalTransferCompleted string = "internal_transfer.completed"
+	InternalTransferFailed    string = "internal_transfer.failed"
+)
+
+// Meta identifies a single event. ID is stable across redeliveries.
+type Meta struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Object references the resource the event is about.
+type Object struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Event is the envelope of every delivery. Data holds the resource as it
+// was when the event fired; use Decode to get it as a typed value.
+type Event struct {
+	APIVersion string          `json:"apiVersion"`
+	Meta       Meta            `json:"event"`
+	Object     Object          `json:"object"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// ID returns the event ID.
+func (e *Event) ID() string {
+	return e.Meta.ID
+}
+
+// Name returns the event name, e.g. CheckoutSessionCompleted.
+func (e *Event) Name() string {
+	return e.Meta.Name
+}
+
+type CheckoutSessionEvent struct {
+	*Event
+	Session checkout.Domain
+}
+
+type PaymentEvent struct {
+	*Event
+	Payment payments.Domain
+}
+
+type PaymentCodeEvent struct {
+	*Event
+	PaymentCode payment_codes.Domain
+}
+
+type PayoutEvent struct {
+	*Event
+	Payout payouts.Domain
+}
+
+type InternalTransferEvent struct {
+	*Event
+	Transfer internal_transfers.Domain
+}
+
+// Decode unmarshals Data according to the event's resource and returns one
+// of *CheckoutSessionEvent, *PaymentEvent, *PaymentCodeEvent, *PayoutEvent or
+// *InternalTransferEvent. Events for other resources are returned as is.
+func (e *Event) Decode() (any, error) {
+	resource, _, _ := strings.Cut(e.Meta.Name, ".")
+
+	switch resource {
+	case "checkout_session":
+		out := &CheckoutSessionEvent{Event: e}
+		return out, json.Unmarshal(e.Data, &out.Session)
+	case "payment":
+		out := &PaymentEvent{Event: e}
+		return out, json.Unmarshal(e.Data, &out.Payment)
+	case "payment_code":
+		out := &PaymentCodeEvent{Event: e}
+		return out, json.Unmarshal(e.Data, &out.PaymentCode)
+	case "payout":
+		out := &PayoutEvent{Event: e}
+		return out, json.Unmarshal(e.Data, &out.Payout)
+	case "internal_transfer":
+		out := &InternalTransferEvent{Event: e}
+		return out, json.Unmarshal(e.Data, &out.Transfer)
+	default:
+		return e, nil
+	}
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxBodyBytes caps the size of a delivery the Handler will read.
+const MaxBodyBytes int64 = 1 << 20
+
+// EventHandler processes a verified delivery. Returning an error makes the
+// Handler answer with a non-2xx status so Monime redelivers the event; see
+// StatusError to control which status is used.
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event *Event) error
+}
+
+// EventHandlerFunc adapts a function to EventHandler.
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+// HandleEvent implements EventHandler.
+func (f EventHandlerFunc) HandleEvent(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// Handler is an http.Handler that verifies and decodes Monime deliveries
+// before passing them to an EventHandler.
+type Handler struct {
+	verifier *Verifier
+	next     EventHandler
+	log      logger.Logger
+	tracer   tracing.Tracer
+}
+
+func NewHandler(verifier *Verifier, next EventHandler, log logger.Logger, tracer tracing.Tracer) *Handler {
+	return &Handler{
+		verifier: verifier,
+		next:     next,
+		log:      log,
+		tracer:   tracer,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "app.webhook.receive.handler", trace.WithAttributes(
+		attribute.String("operation", "RECEIVE"),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		h.fail(span, traceId, "failed to read webhook body", err)
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verifier.Verify(r.Header.Get(SignatureHeader), body); err != nil {
+		h.fail(span, traceId, "webhook signature rejected", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.fail(span, traceId, "failed to decode webhook event", err)
+		http.Error(w, "malformed event", http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID()),
+		attribute.String("event.name", event.Name()),
+	)
+
+	if err := h.next.HandleEvent(ctx, &event); err != nil {
+		h.fail(span, traceId, "failed to handle webhook event", err,
+			zap.String("event_id", event.ID()),
+			zap.String("event_name", event.Name()),
+		)
+		status := statusFor(err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	h.log.Info("webhook event handled",
+		zap.String("trace_id", traceId),
+		zap.String("event_id", event.ID()),
+		zap.String("event_name", event.Name()),
+	)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) fail(span trace.Span, traceId, msg string, err error, fields ...any) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	h.log.Error(msg, append([]any{
+		zap.String("trace_id", traceId),
+		zap.Error(err),
+	}, fields...)...)
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/internal/nop"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestHandlerStatus(t *testing.T) {
+	const secret = "whsec_current"
+
+	handled := EventHandlerFunc(func(context.Context, *Event) error { return nil })
+	failing := EventHandlerFunc(func(context.Context, *Event) error { return Permanent(errors.New("unknown order")) })
+
+	tests := []struct {
+		name   string
+		method string
+		body   io.Reader
+		header string
+		next   EventHandler
+		want   int
+	}{
+		{"handled", http.MethodPost, bytes.NewReader(testBody), SignHMAC(secret, time.Now(), testBody), handled, http.StatusOK},
+		{"wrong method", http.MethodGet, nil, "", handled, http.StatusMethodNotAllowed},
+		{"body too large", http.MethodPost, bytes.NewReader(make([]byte, MaxBodyBytes+1)), "", handled, http.StatusRequestEntityTooLarge},
+		{"body read fails", http.MethodPost, failingReader{}, "", handled, http.StatusBadRequest},
+		{"bad signature", http.MethodPost, bytes.NewReader(testBody), SignHMAC("whsec_other", time.Now(), testBody), handled, http.StatusUnauthorized},
+		{"malformed event", http.MethodPost, bytes.NewReader([]byte("{")), SignHMAC(secret, time.Now(), []byte("{")), handled, http.StatusBadRequest},
+		{"handler error", http.MethodPost, bytes.NewReader(testBody), SignHMAC(secret, time.Now(), testBody), failing, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(NewHMACVerifier(secret, 0), tt.next, nop.Logger{}, nop.Tracer{})
+
+			req := httptest.NewRequest(tt.method, "/webhooks/monime", tt.body)
+			if tt.header != "" {
+				req.Header.Set(SignatureHeader, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/ose-micro/core/logger"
+	"github.com/ose-micro/core/tracing"
+	"github.com/ose-micro/monime/services/checkout"
+	"github.com/ose-micro/monime/services/internal_transfers"
+	"github.com/ose-micro/monime/services/payment_codes"
+	"github.com/ose-micro/monime/services/payments"
+	"github.com/ose-micro/monime/services/payouts"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Router dispatches events to handlers registered by event name. Events
+// without a handler go to the fallback, or are acknowledged if none is set.
+type Router struct {
+	handlers map[string]EventHandlerFunc
+	fallback EventHandlerFunc
+	log      logger.Logger
+	tracer   tracing.Tracer
+}
+
+func NewRouter(log logger.Logger, tracer tracing.Tracer) *Router {
+	return &Router{
+		handlers: make(map[string]EventHandlerFunc),
+		log:      log,
+		tracer:   tracer,
+	}
+}
+
+// On registers fn for the named event, replacing any previous handler.
+func (r *Router) On(name string, fn EventHandlerFunc) {
+	r.handlers[name] = fn
+}
+
+// Fallback registers fn for events that have no handler of their own.
+func (r *Router) Fallback(fn EventHandlerFunc) {
+	r.fallback = fn
+}
+
+// HandleEvent implements EventHandler.
+func (r *Router) HandleEvent(ctx context.Context, event *Event) (err error) {
+	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("app.webhook.%s.handler", event.Name()), trace.WithAttributes(
+		attribute.String("operation", "DISPATCH"),
+		attribute.String("event.id", event.ID()),
+		attribute.String("event.name", event.Name()),
+	))
+	defer span.End()
+
+	traceId := trace.SpanContextFromContext(ctx).TraceID().String()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("webhook: handler for %s panicked: %v", event.Name(), rec)
+			r.log.Error("webhook handler panicked",
+				zap.String("trace_id", traceId),
+				zap.String("event_id", event.ID()),
+				zap.String("event_name", event.Name()),
+				zap.String("stack", string(debug.Stack())),
+			)
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			r.log.Error("failed to dispatch webhook event",
+				zap.String("trace_id", traceId),
+				zap.String("event_id", event.ID()),
+				zap.String("event_name", event.Name()),
+				zap.Int("status", statusFor(err)),
+				zap.Error(err),
+			)
+		}
+	}()
+
+	fn, ok := r.handlers[event.Name()]
+	if !ok {
+		fn = r.fallback
+	}
+
+	if fn == nil {
+		r.log.Info("webhook event ignored",
+			zap.String("trace_id", traceId),
+			zap.String("event_id", event.ID()),
+			zap.String("event_name", event.Name()),
+		)
+		return nil
+	}
+
+	if err := fn(ctx, event); err != nil {
+		return err
+	}
+
+	r.log.Info("webhook event dispatched",
+		zap.String("trace_id", traceId),
+		zap.String("event_id", event.ID()),
+		zap.String("event_name", event.Name()),
+	)
+
+	return nil
+}
+
+// typed adapts a handler taking a resource to EventHandlerFunc. A payload
+// that does not decode into E is answered with 400 Bad Request.
+func typed[E any, T any](fn func(context.Context, T) error, data func(E) T) EventHandlerFunc {
+	return func(ctx context.Context, event *Event) error {
+		decoded, err := event.Decode()
+		if err != nil {
+			return &StatusError{Status: http.StatusBadRequest, Err: err}
+		}
+
+		e, ok := decoded.(E)
+		if !ok {
+			return &StatusError{
+				Status: http.StatusBadRequest,
+				Err:    fmt.Errorf("unexpected payload %T for %s", decoded, event.Name()),
+			}
+		}
+
+		return fn(ctx, data(e))
+	}
+}
+
+func checkoutSession(fn func(context.Context, checkout.Domain) error) EventHandlerFunc {
+	return typed(fn, func(e *CheckoutSessionEvent) checkout.Domain { return e.Session })
+}
+
+func payment(fn func(context.Context, payments.Domain) error) EventHandlerFunc {
+	return typed(fn, func(e *PaymentEvent) payments.Domain { return e.Payment })
+}
+
+func paymentCode(fn func(context.Context, payment_codes.Domain) error) EventHandlerFunc {
+	return typed(fn, func(e *PaymentCodeEvent) payment_codes.Domain { return e.PaymentCode })
+}
+
+func payout(fn func(context.Context, payouts.Domain) error) EventHandlerFunc {
+	return typed(fn, func(e *PayoutEvent) payouts.Domain { return e.Payout })
+}
+
+func internalTransfer(fn func(context.Context, internal_transfers.Domain) error) EventHandlerFunc {
+	return typed(fn, func(e *InternalTransferEvent) internal_transfers.Domain { return e.Transfer })
+}
+
+func (r *Router) OnCheckoutSessionCompleted(fn func(ctx context.Context, session checkout.Domain) error) {
+	r.On(CheckoutSessionCompleted, checkoutSession(fn))
+}
+
+func (r *Router) OnCheckoutSessionExpired(fn func(ctx context.Context, session checkout.Domain) error) {
+	r.On(CheckoutSessionExpired, checkoutSession(fn))
+}
+
+func (r *Router) OnCheckoutSessionCancelled(fn func(ctx context.Context, session checkout.Domain) error) {
+	r.On(CheckoutSessionCancelled, checkoutSession(fn))
+}
+
+func (r *Router) OnPaymentCreated(fn func(ctx context.Context, payment payments.Domain) error) {
+	r.On(PaymentCreated, payment(fn))
+}
+
+func (r *Router) OnPaymentCompleted(fn func(ctx context.Context, payment payments.Domain) error) {
+	r.On(PaymentCompleted, payment(fn))
+}
+
+func (r *Router) OnPaymentFailed(fn func(ctx context.Context, payment payments.Domain) error) {
+	r.On(PaymentFailed, payment(fn))
+}
+
+func (r *Router) OnPaymentCodeCreated(fn func(ctx context.Context, code payment_codes.Domain) error) {
+	r.On(PaymentCodeCreated, paymentCode(fn))
+}
+
+func (r *Router) OnPaymentCodeCompleted(fn func(ctx context.Context, code payment_codes.Domain) error) {
+	r.On(PaymentCodeCompleted, paymentCode(fn))
+}
+
+func (r *Router) OnPaymentCodeExpired(fn func(ctx context.Context, code payment_codes.Domain) error) {
+	r.On(PaymentCodeExpired, paymentCode(fn))
+}
+
+func (r *Router) OnPayoutCompleted(fn func(ctx context.Context, payout payouts.Domain) error) {
+	r.On(PayoutCompleted, payout(fn))
+}
+
+func (r *Router) OnPayoutFailed(fn func(ctx context.Context, payout payouts.Domain) error) {
+	r.On(PayoutFailed, payout(fn))
+}
+
+func (r *Router) OnInternalTransferCompleted(fn func(ctx context.Context, transfer internal_transfers.Domain) error) {
+	r.On(InternalTransferCompleted, internalTransfer(fn))
+}
+
+func (r *Router) OnInternalTransferFailed(fn func(ctx context.Context, transfer internal_transfers.Domain) error) {
+	r.On(InternalTransferFailed, internalTransfer(fn))
+}
+
+var _ EventHandler = (*Router)(nil)
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ose-micro/monime/internal/nop"
+	"github.com/ose-micro/monime/services/payments"
+	"github.com/ose-micro/monime/services/payouts"
+)
+
+func newEvent(name, data string) *Event {
+	return &Event{Meta: Meta{ID: "evt-1", Name: name}, Data: json.RawMessage(data)}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	var got []string
+
+	r := NewRouter(nop.Logger{}, nop.Tracer{})
+	r.OnPaymentCompleted(func(_ context.Context, p payments.Domain) error {
+		got = append(got, "payment "+p.ID)
+		return nil
+	})
+	r.OnPayoutFailed(func(_ context.Context, p payouts.Domain) error {
+		got = append(got, "payout "+p.ID)
+		return nil
+	})
+
+	events := []*Event{
+		newEvent(PaymentCompleted, `{"id":"pay-1"}`),
+		newEvent(PayoutFailed, `{"id":"pyt-1"}`),
+		newEvent(PaymentFailed, `{"id":"pay-2"}`),
+	}
+	for _, event := range events {
+		if err := r.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("HandleEvent(%s) = %v", event.Name(), err)
+		}
+	}
+
+	if want := "payment pay-1,payout pyt-1"; strings.Join(got, ",") != want {
+		t.Fatalf("dispatched %v, want %s", got, want)
+	}
+}
+
+func TestRouterFallback(t *testing.T) {
+	var got []string
+
+	r := NewRouter(nop.Logger{}, nop.Tracer{})
+	r.OnPaymentCompleted(func(context.Context, payments.Domain) error {
+		got = append(got, "payment")
+		return nil
+	})
+	r.Fallback(func(_ context.Context, event *Event) error {
+		got = append(got, "fallback "+event.Name())
+		return nil
+	})
+
+	for _, name := range []string{PaymentCompleted, PayoutCompleted, "account.updated"} {
+		if err := r.HandleEvent(context.Background(), newEvent(name, `{}`)); err != nil {
+			t.Fatalf("HandleEvent(%s) = %v", name, err)
+		}
+	}
+
+	if want := "payment,fallback payout.completed,fallback account.updated"; strings.Join(got, ",") != want {
+		t.Fatalf("dispatched %v, want %s", got, want)
+	}
+}
+
+func TestRouterStatus(t *testing.T) {
+	fails := func(err error) func(context.Context, payments.Domain) error {
+		return func(context.Context, payments.Domain) error { return err }
+	}
+
+	tests := []struct {
+		name  string
+		setup func(r *Router)
+		event *Event
+		want  int
+	}{
+		{"handled", func(r *Router) { r.OnPaymentCompleted(fails(nil)) }, newEvent(PaymentCompleted, `{"id":"pay-1"}`), http.StatusOK},
+		{"plain error", func(r *Router) { r.OnPaymentCompleted(fails(errors.New("db down"))) }, newEvent(PaymentCompleted, `{}`), http.StatusInternalServerError},
+		{"permanent", func(r *Router) { r.OnPaymentCompleted(fails(Permanent(errors.New("unknown order")))) }, newEvent(PaymentCompleted, `{}`), http.StatusUnprocessableEntity},
+		{"retry", func(r *Router) { r.OnPaymentCompleted(fails(Retry(errors.New("ledger busy")))) }, newEvent(PaymentCompleted, `{}`), http.StatusServiceUnavailable},
+		{"custom status", func(r *Router) {
+			r.OnPaymentCompleted(fails(&StatusError{Status: http.StatusConflict, Err: errors.New("stale")}))
+		}, newEvent(PaymentCompleted, `{}`), http.StatusConflict},
+		{"payload does not decode", func(r *Router) { r.OnPaymentCompleted(fails(nil)) }, newEvent(PaymentCompleted, `{"id":42}`), http.StatusBadRequest},
+		{"payload of another resource", func(r *Router) {
+			r.On(PaymentCompleted, payout(func(context.Context, payouts.Domain) error { return nil }))
+		}, newEvent(PaymentCompleted, `{"id":"pay-1"}`), http.StatusBadRequest},
+		{"panic", func(r *Router) {
+			r.OnPaymentCompleted(func(context.Context, payments.Domain) error { panic("nil map") })
+		}, newEvent(PaymentCompleted, `{}`), http.StatusInternalServerError},
+		{"panic in fallback", func(r *Router) {
+			r.Fallback(func(context.Context, *Event) error { panic("nil map") })
+		}, newEvent("account.updated", `{}`), http.StatusInternalServerError},
+		{"no handler", func(*Router) {}, newEvent(PaymentCompleted, `{}`), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRouter(nop.Logger{}, nop.Tracer{})
+			tt.setup(r)
+
+			got := http.StatusOK
+			if err := r.HandleEvent(context.Background(), tt.event); err != nil {
+				got = statusFor(err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerRecoversRouterPanic(t *testing.T) {
+	const secret = "whsec_current"
+
+	r := NewRouter(nop.Logger{}, nop.Tracer{})
+	r.OnPaymentCompleted(func(context.Context, payments.Domain) error { panic("nil map") })
+
+	body := []byte(`{"event":{"id":"evt-1","name":"payment.completed"},"data":{"id":"pay-1"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/monime", strings.NewReader(string(body)))
+	req.Header.Set(SignatureHeader, SignHMAC(secret, time.Now(), body))
+
+	rec := httptest.NewRecorder()
+	NewHandler(NewHMACVerifier(secret, 0), r, nop.Logger{}, nop.Tracer{}).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
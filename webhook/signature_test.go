@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testNow  = time.Unix(1_750_000_000, 0)
+	testBody = []byte(`{"apiVersion":"caph.2025-06-20","event":{"id":"evt-1","name":"payment.completed"}}`)
+)
+
+func fixedClock(v *Verifier) *Verifier {
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestHMACVerify(t *testing.T) {
+	v := fixedClock(NewHMACVerifier("whsec_current", time.Minute))
+
+	// rotated combines the signatures of the old and new secret under one
+	// timestamp, as Monime sends while a secret is being rotated
+	rotated := func(first, second string) string {
+		a := SignHMAC(first, testNow, testBody)
+		b := SignHMAC(second, testNow, testBody)
+		return a + "," + b[strings.Index(b, "v1="):]
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   error
+	}{
+		{"valid", SignHMAC("whsec_current", testNow, testBody), testBody, nil},
+		{"within tolerance in the past", SignHMAC("whsec_current", testNow.Add(-59*time.Second), testBody), testBody, nil},
+		{"within tolerance in the future", SignHMAC("whsec_current", testNow.Add(59*time.Second), testBody), testBody, nil},
+		{"too old", SignHMAC("whsec_current", testNow.Add(-61*time.Second), testBody), testBody, ErrTimestampExpired},
+		{"too far in the future", SignHMAC("whsec_current", testNow.Add(61*time.Second), testBody), testBody, ErrTimestampExpired},
+		{"rotation with new secret second", rotated("whsec_previous", "whsec_current"), testBody, nil},
+		{"rotation with new secret first", rotated("whsec_current", "whsec_previous"), testBody, nil},
+		{"wrong secret", SignHMAC("whsec_other", testNow, testBody), testBody, ErrInvalidSignature},
+		{"tampered body", SignHMAC("whsec_current", testNow, testBody), []byte(`{"tampered":true}`), ErrInvalidSignature},
+		{"signature not base64", fmt.Sprintf("t=%d,v1=%%%%%%", testNow.Unix()), testBody, ErrInvalidSignature},
+		{"missing header", "", testBody, ErrMissingSignature},
+		{"no key value pairs", "garbage", testBody, ErrMalformedHeader},
+		{"timestamp not a number", "t=yesterday,v1=c2ln", testBody, ErrMalformedHeader},
+		{"no timestamp", "v1=c2ln", testBody, ErrMalformedHeader},
+		{"no signature", fmt.Sprintf("t=%d", testNow.Unix()), testBody, ErrMalformedHeader},
+		{"unknown scheme only", fmt.Sprintf("t=%d,v0=c2ln", testNow.Unix()), testBody, ErrMalformedHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.Verify(tt.header, tt.body); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHMACVerifyDefaultTolerance(t *testing.T) {
+	v := fixedClock(NewHMACVerifier("whsec_current", 0))
+
+	if err := v.Verify(SignHMAC("whsec_current", testNow.Add(-DefaultTolerance+time.Second), testBody), testBody); err != nil {
+		t.Fatalf("Verify() inside DefaultTolerance = %v, want nil", err)
+	}
+
+	if err := v.Verify(SignHMAC("whsec_current", testNow.Add(-DefaultTolerance-time.Second), testBody), testBody); !errors.Is(err, ErrTimestampExpired) {
+		t.Fatalf("Verify() outside DefaultTolerance = %v, want %v", err, ErrTimestampExpired)
+	}
+}
+
+func TestES256Verify(t *testing.T) {
+	key := newKey(t)
+	other := newKey(t)
+
+	v, err := NewES256Verifier(publicPEM(t, key), time.Minute)
+	if err != nil {
+		t.Fatalf("NewES256Verifier() = %v", err)
+	}
+	fixedClock(v)
+
+	sign := func(key *ecdsa.PrivateKey, at time.Time, body []byte) string {
+		header, err := SignES256(key, at, body)
+		if err != nil {
+			t.Fatalf("SignES256() = %v", err)
+		}
+		return header
+	}
+
+	rotated := sign(other, testNow, testBody)
+	current := sign(key, testNow, testBody)
+	rotated += "," + current[strings.Index(current, "v1="):]
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   error
+	}{
+		{"valid", sign(key, testNow, testBody), testBody, nil},
+		{"within tolerance", sign(key, testNow.Add(-59*time.Second), testBody), testBody, nil},
+		{"too old", sign(key, testNow.Add(-61*time.Second), testBody), testBody, ErrTimestampExpired},
+		{"rotation", rotated, testBody, nil},
+		{"other key", sign(other, testNow, testBody), testBody, ErrInvalidSignature},
+		{"tampered body", sign(key, testNow, testBody), []byte(`{}`), ErrInvalidSignature},
+		{"hmac signature", SignHMAC("whsec_current", testNow, testBody), testBody, ErrInvalidSignature},
+		{"malformed", "t=,v1=", testBody, ErrMalformedHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.Verify(tt.header, tt.body); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewES256VerifierRejectsBadKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+	}{
+		{"not pem", []byte("not a key")},
+		{"not pkix", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewES256Verifier(tt.key, 0); err == nil {
+				t.Fatal("NewES256Verifier() = nil error, want error")
+			}
+		})
+	}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() = %v", err)
+	}
+	return key
+}
+
+func publicPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() = %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}